@@ -0,0 +1,229 @@
+package bubble_bath
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AnimatedComponent is a component that owns one or more Animations
+type AnimatedComponent interface {
+	Component
+
+	// HandleAnimationFrame advances the component's animations that the frame is for (see AnimationFrameMsg.IsFrameFor)
+	HandleAnimationFrame(msg AnimationFrameMsg) tea.Cmd
+}
+
+// Animation is a component's registration with the program's central ticker, which delivers a single
+// AnimationFrameMsg to every AnimatedComponent in the tree each time any running animation is due a frame
+// This way many components animating at once (e.g. a spinner, a blinking cursor, and a screen sliding in) share one
+// stream of ticks rather than each flooding the event loop with their own tea.Ticks, and the ticker stops ticking
+// entirely when nothing is animating
+// Animations are started & stopped from the component's Update (or anything called from it), and only run under the
+// program (see NewBubbleBathModel)
+type Animation struct {
+	interval time.Duration
+
+	isRunning bool
+
+	startTime time.Time
+
+	// When the animation last got a frame, or zero if it hasn't had one since it was started
+	lastFrameTime time.Time
+}
+
+// NewAnimation creates an animation that wants the given number of frames per second
+func NewAnimation(fps int) *Animation {
+	return &Animation{
+		interval:      time.Second / time.Duration(GetMaxInt(1, fps)),
+		isRunning:     false,
+		startTime:     time.Time{},
+		lastFrameTime: time.Time{},
+	}
+}
+
+// Start starts (or restarts) the animation, returning the command that registers it with the program's ticker
+// The first frame comes as soon as the ticker next ticks
+func (animation *Animation) Start() tea.Cmd {
+	animation.isRunning = true
+	animation.startTime = time.Now()
+	animation.lastFrameTime = time.Time{}
+	return func() tea.Msg {
+		return animationStartedMsg{animation: animation}
+	}
+}
+
+// Stop stops the animation getting frames, which it won't get again until it's started again
+func (animation *Animation) Stop() {
+	animation.isRunning = false
+}
+
+func (animation *Animation) IsRunning() bool {
+	return animation.isRunning
+}
+
+// Reset holds off the animation's next frame for a full interval from now (e.g. so that a blinking cursor stays visible
+// while the user is typing)
+func (animation *Animation) Reset() {
+	animation.lastFrameTime = time.Now()
+}
+
+func (animation *Animation) GetInterval() time.Duration {
+	return animation.interval
+}
+
+// GetElapsed gets how long the animation has been running for as of the given time (e.g. an AnimationFrameMsg's), for
+// animations that play over a set duration (see GetEasedProgress)
+func (animation *Animation) GetElapsed(now time.Time) time.Duration {
+	if animation.startTime.IsZero() {
+		return 0
+	}
+	return now.Sub(animation.startTime)
+}
+
+// AnimationFrameMsg is delivered to every AnimatedComponent in the tree (see AnimateTree) whenever any running
+// animation is due a frame, so components should check that it's for one of their own animations (see IsFrameFor)
+// before acting on it
+// It's delivered straight to the components rather than through Update, so that they get their frames whether or not
+// they're focused
+type AnimationFrameMsg struct {
+	Time time.Time
+
+	dueAnimations map[*Animation]bool
+}
+
+// IsFrameFor indicates whether the frame is one that the animation is due
+func (msg AnimationFrameMsg) IsFrameFor(animation *Animation) bool {
+	return msg.dueAnimations[animation]
+}
+
+// AnimateTree delivers the frame to every AnimatedComponent in the tree rooted at the given component, batching the
+// commands they return
+// Hidden children (see HiddenChildrenContainerComponent) get it too, so that e.g. a spinner beneath an overlay keeps
+// spinning, and stops when its task is done rather than holding the ticker open
+func AnimateTree(root Component, msg AnimationFrameMsg) tea.Cmd {
+	cmds := make([]tea.Cmd, 0)
+	if animatedComponent, ok := root.(AnimatedComponent); ok {
+		cmds = append(cmds, animatedComponent.HandleAnimationFrame(msg))
+	}
+	for _, child := range getAllChildren(root) {
+		cmds = append(cmds, AnimateTree(child, msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+// animationStartedMsg registers an animation with the program's ticker
+type animationStartedMsg struct {
+	animation *Animation
+}
+
+// animationTickMsg is the central ticker's tick, which becomes an AnimationFrameMsg if any animation is due a frame
+type animationTickMsg struct {
+	// So that ticks from a chain that got replaced (by a faster one) are ignored
+	chainID int
+
+	time time.Time
+}
+
+// animationTicker is the program's central ticker, which ticks at the rate of its fastest running animation and
+// stops once none are left
+type animationTicker struct {
+	animations []*Animation
+
+	// Zero while the ticker is paused
+	tickInterval time.Duration
+
+	chainID int
+}
+
+func newAnimationTicker() *animationTicker {
+	return &animationTicker{
+		animations:   make([]*Animation, 0),
+		tickInterval: 0,
+		chainID:      0,
+	}
+}
+
+// register adds the animation, returning the command that starts the ticker ticking (or ticking faster) if needed
+func (ticker *animationTicker) register(animation *Animation) tea.Cmd {
+	if !animation.isRunning {
+		return nil
+	}
+
+	isRegistered := false
+	for _, candidate := range ticker.animations {
+		if candidate == animation {
+			isRegistered = true
+			break
+		}
+	}
+	if !isRegistered {
+		ticker.animations = append(ticker.animations, animation)
+	}
+
+	if ticker.tickInterval != 0 && ticker.tickInterval <= animation.interval {
+		// The ticker's already ticking fast enough
+		return nil
+	}
+	return ticker.startChain(animation.interval)
+}
+
+// tick gets the frame for the animations that are due one (if any are), and the command for the next tick (if any
+// animations are still running)
+func (ticker *animationTicker) tick(msg animationTickMsg) (AnimationFrameMsg, tea.Cmd) {
+	frame := AnimationFrameMsg{
+		Time:          msg.time,
+		dueAnimations: map[*Animation]bool{},
+	}
+	if msg.chainID != ticker.chainID || ticker.tickInterval == 0 {
+		return frame, nil
+	}
+
+	running := make([]*Animation, 0, len(ticker.animations))
+	fastestInterval := time.Duration(0)
+	for _, animation := range ticker.animations {
+		if !animation.isRunning {
+			continue
+		}
+		running = append(running, animation)
+		if fastestInterval == 0 || animation.interval < fastestInterval {
+			fastestInterval = animation.interval
+		}
+
+		// Ticks only come every tick interval, so an animation is due a frame if waiting for the next tick would make
+		// its frame later than it'd be by coming now
+		sinceLastFrame := msg.time.Sub(animation.lastFrameTime)
+		if animation.lastFrameTime.IsZero() || sinceLastFrame >= animation.interval-ticker.tickInterval/2 {
+			frame.dueAnimations[animation] = true
+			animation.lastFrameTime = msg.time
+		}
+	}
+	ticker.animations = running
+
+	if len(running) == 0 {
+		// Nothing's animating, so the ticker pauses until something starts again
+		ticker.tickInterval = 0
+		return frame, nil
+	}
+	if fastestInterval != ticker.tickInterval {
+		return frame, ticker.startChain(fastestInterval)
+	}
+	return frame, ticker.getTickCmd()
+}
+
+// startChain starts ticking at the given interval, replacing any tick chain that's already going
+func (ticker *animationTicker) startChain(interval time.Duration) tea.Cmd {
+	ticker.chainID++
+	ticker.tickInterval = interval
+	return ticker.getTickCmd()
+}
+
+func (ticker *animationTicker) getTickCmd() tea.Cmd {
+	chainID := ticker.chainID
+	return tea.Tick(ticker.tickInterval, func(now time.Time) tea.Msg {
+		return animationTickMsg{
+			chainID: chainID,
+			time:    now,
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package bubble_bath
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// animatedFake counts the frames it gets for its animation
+type animatedFake struct {
+	animation *Animation
+
+	frameCount int
+}
+
+func (fake *animatedFake) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (fake *animatedFake) View() string {
+	return ""
+}
+
+func (fake *animatedFake) Resize(width int, height int) {}
+
+func (fake *animatedFake) GetWidth() int {
+	return 0
+}
+
+func (fake *animatedFake) GetHeight() int {
+	return 0
+}
+
+func (fake *animatedFake) SetFocus(isFocused bool) tea.Cmd {
+	return nil
+}
+
+func (fake *animatedFake) IsFocused() bool {
+	return false
+}
+
+func (fake *animatedFake) HandleAnimationFrame(msg AnimationFrameMsg) tea.Cmd {
+	if msg.IsFrameFor(fake.animation) {
+		fake.frameCount++
+	}
+	return nil
+}
+
+func TestAnimateTreeReachesComponentsBeneathOverlays(t *testing.T) {
+	base := &animatedFake{animation: NewAnimation(10), frameCount: 0}
+	overlay := &animatedFake{animation: NewAnimation(10), frameCount: 0}
+	stack := NewOverlayStack(base)
+	stack.Push(Overlay{Component: overlay, Position: OverlayCentered(), Width: 1, Height: 1})
+
+	AnimateTree(stack, AnimationFrameMsg{
+		Time: time.Now(),
+		dueAnimations: map[*Animation]bool{
+			base.animation:    true,
+			overlay.animation: true,
+		},
+	})
+	if base.frameCount != 1 {
+		t.Errorf("Expected the base beneath the overlay to get 1 frame, but it got %v", base.frameCount)
+	}
+	if overlay.frameCount != 1 {
+		t.Errorf("Expected the overlay to get 1 frame, but it got %v", overlay.frameCount)
+	}
+}
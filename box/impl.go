@@ -0,0 +1,267 @@
+package box
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// StyleType is the name that style sheet type selectors use to match boxes
+// Boxes are in the bubble_bath.StyleStateFocused state when focused
+const StyleType = "box"
+
+type BoxOption func(*implementation)
+
+// WithPadding sets the space between the border and the inner component, using the CSS shorthand (see
+// bubble_bath.Style.Padding)
+func WithPadding(sizes ...int) BoxOption {
+	return func(impl *implementation) {
+		impl.inlineStyle = impl.inlineStyle.Padding(sizes...)
+	}
+}
+
+// WithMargin sets the space outside the border, using the CSS shorthand (see bubble_bath.Style.Padding)
+func WithMargin(sizes ...int) BoxOption {
+	return func(impl *implementation) {
+		impl.inlineStyle = impl.inlineStyle.Margin(sizes...)
+	}
+}
+
+// WithBorder draws a border, optionally only on some sides using the CSS shorthand (see bubble_bath.Style.Border)
+func WithBorder(border lipgloss.Border, sides ...bool) BoxOption {
+	return func(impl *implementation) {
+		impl.inlineStyle = impl.inlineStyle.Border(border, sides...)
+	}
+}
+
+func WithBorderColor(color lipgloss.TerminalColor) BoxOption {
+	return func(impl *implementation) {
+		impl.inlineStyle = impl.inlineStyle.BorderForeground(color)
+	}
+}
+
+// WithFocusedBorderColor sets the border color used while the box is focused
+func WithFocusedBorderColor(color lipgloss.TerminalColor) BoxOption {
+	return func(impl *implementation) {
+		impl.inlineFocusedStyle = impl.inlineFocusedStyle.BorderForeground(color)
+	}
+}
+
+// WithTitle sets the title shown in the top border
+func WithTitle(title string) BoxOption {
+	return func(impl *implementation) {
+		impl.title = title
+	}
+}
+
+type implementation struct {
+	bubble_bath.StyleNode
+
+	inner bubble_bath.Component
+
+	// Styles set through the options, which beat the style sheet's
+	inlineStyle bubble_bath.Style
+
+	// Layered on top of the inline style while focused
+	inlineFocusedStyle bubble_bath.Style
+
+	title string
+
+	theme bubble_bath.Theme
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+func New(inner bubble_bath.Component, opts ...BoxOption) Component {
+	result := &implementation{
+		StyleNode:          bubble_bath.StyleNode{},
+		inner:              inner,
+		inlineStyle:        bubble_bath.NewStyle(),
+		inlineFocusedStyle: bubble_bath.NewStyle(),
+		title:              "",
+		theme:              bubble_bath.DarkTheme,
+		isFocused:          false,
+		width:              0,
+		height:             0,
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+	return result
+}
+
+func (impl *implementation) GetInner() bubble_bath.Component {
+	return impl.inner
+}
+
+func (impl *implementation) SetTitle(title string) {
+	impl.title = title
+}
+
+func (impl *implementation) GetTitle() string {
+	return impl.title
+}
+
+func (impl *implementation) Update(msg tea.Msg) tea.Cmd {
+	if themeChangedMsg, ok := msg.(bubble_bath.ThemeChangedMsg); ok {
+		impl.SetTheme(themeChangedMsg.Current)
+		return bubble_bath.BroadcastThemeChange(impl.GetChildren(), themeChangedMsg)
+	}
+
+	interactiveInner, ok := impl.inner.(bubble_bath.InteractiveComponent)
+	if !ok {
+		return nil
+	}
+	return interactiveInner.Update(msg)
+}
+
+func (impl *implementation) View() string {
+	return bubble_bath.RenderDrawable(impl)
+}
+
+// Draw draws the frame (margins, border, padding, and title), and then the inner component inside it
+func (impl *implementation) Draw(canvas *bubble_bath.Canvas) {
+	style := impl.getEffectiveStyle()
+
+	// Rendering the style around nothing gets lipgloss to draw the frame for us
+	canvas.DrawView(0, 0, style.RenderWithin("", impl.width, impl.height))
+
+	lipglossStyle := style.ToLipgloss()
+	if impl.title != "" && lipglossStyle.GetBorderTopSize() > 0 {
+		impl.drawTitle(canvas, lipglossStyle)
+	}
+
+	bubble_bath.DrawComponent(canvas.SubCanvas(impl.getInnerRectangle()), impl.inner)
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+
+	innerRectangle := impl.getInnerRectangle()
+	impl.inner.Resize(innerRectangle.Width, innerRectangle.Height)
+}
+
+func (impl *implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl *implementation) GetHeight() int {
+	return impl.height
+}
+
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	return bubble_bath.GetMinimumIntrinsicWidth(impl.inner) + impl.getEffectiveStyle().GetHorizontalFrameSize()
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return bubble_bath.GetMaximumIntrinsicWidth(impl.inner) + impl.getEffectiveStyle().GetHorizontalFrameSize()
+}
+
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	style := impl.getEffectiveStyle()
+	innerWidth := bubble_bath.GetMaxInt(0, width-style.GetHorizontalFrameSize())
+	return bubble_bath.GetHeightGivenWidth(impl.inner, innerWidth) + style.GetVerticalFrameSize()
+}
+
+func (impl *implementation) GetChildren() []bubble_bath.Component {
+	return []bubble_bath.Component{impl.inner}
+}
+
+func (impl *implementation) GetChildRectangles() []bubble_bath.Rectangle {
+	return []bubble_bath.Rectangle{impl.getInnerRectangle()}
+}
+
+func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
+	impl.isFocused = isFocused
+
+	interactiveInner, ok := impl.inner.(bubble_bath.InteractiveComponent)
+	if !ok {
+		return nil
+	}
+	return interactiveInner.SetFocus(isFocused)
+}
+
+func (impl *implementation) IsFocused() bool {
+	return impl.isFocused
+}
+
+// IsFocusable indicates whether the inner component can be focused, since the box is only a wrapper
+func (impl *implementation) IsFocusable() bool {
+	return bubble_bath.IsFocusable(impl.inner)
+}
+
+func (impl *implementation) SetTheme(theme bubble_bath.Theme) {
+	impl.theme = theme
+}
+
+func (impl *implementation) GetStyleType() string {
+	return StyleType
+}
+
+func (impl *implementation) HasStyleState(state bubble_bath.StyleState) bool {
+	return state == bubble_bath.StyleStateFocused && impl.isFocused
+}
+
+// SetStyle sets the style from the style sheet, resizing the inner component if the frame changed size
+func (impl *implementation) SetStyle(style bubble_bath.Style) {
+	oldInnerRectangle := impl.getInnerRectangle()
+	impl.StyleNode.SetStyle(style)
+	if impl.getInnerRectangle() != oldInnerRectangle {
+		impl.Resize(impl.width, impl.height)
+	}
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// getEffectiveStyle layers the inline styles on top of the style sheet's, on top of the theme's border colors
+func (impl *implementation) getEffectiveStyle() bubble_bath.Style {
+	borderColor := impl.theme.Border
+	if impl.isFocused {
+		borderColor = impl.theme.BorderFocused
+	}
+	result := bubble_bath.NewStyle().BorderForeground(borderColor).Merge(impl.GetStyle()).Merge(impl.inlineStyle)
+	if impl.isFocused {
+		result = result.Merge(impl.inlineFocusedStyle)
+	}
+	return result
+}
+
+// getInnerRectangle gets the space left for the inner component inside the margins, border, and padding
+func (impl *implementation) getInnerRectangle() bubble_bath.Rectangle {
+	style := impl.getEffectiveStyle()
+	lipglossStyle := style.ToLipgloss()
+	return bubble_bath.Rectangle{
+		X:      lipglossStyle.GetMarginLeft() + lipglossStyle.GetBorderLeftSize() + lipglossStyle.GetPaddingLeft(),
+		Y:      lipglossStyle.GetMarginTop() + lipglossStyle.GetBorderTopSize() + lipglossStyle.GetPaddingTop(),
+		Width:  bubble_bath.GetMaxInt(0, impl.width-style.GetHorizontalFrameSize()),
+		Height: bubble_bath.GetMaxInt(0, impl.height-style.GetVerticalFrameSize()),
+	}
+}
+
+// drawTitle writes the title over the top border, just inside the corner, in the border's style
+func (impl *implementation) drawTitle(canvas *bubble_bath.Canvas, lipglossStyle lipgloss.Style) {
+	borderY := lipglossStyle.GetMarginTop()
+	borderLeft := lipglossStyle.GetMarginLeft()
+	borderRight := impl.width - lipglossStyle.GetMarginRight()
+
+	// The title can't cover either corner
+	titleStartX := borderLeft + lipglossStyle.GetBorderLeftSize()
+	titleEndX := borderRight - lipglossStyle.GetBorderRightSize()
+	if titleEndX <= titleStartX {
+		return
+	}
+
+	titleStyle := canvas.GetCell(titleStartX, borderY).Style
+	titleCanvas := canvas.SubCanvas(bubble_bath.Rectangle{
+		X:      titleStartX,
+		Y:      borderY,
+		Width:  titleEndX - titleStartX,
+		Height: 1,
+	})
+	titleCanvas.DrawString(0, 0, " "+impl.title+" ", titleStyle)
+}
@@ -0,0 +1,23 @@
+package box
+
+import bubble_bath "github.com/mieubrisse/bubble-bath"
+
+// Component wraps another component in the CSS box model: padding, then a border (optionally titled), then margin
+// Its frame comes from its inline options with any style sheet rules matching it layered beneath, so both the options
+// and the sheet (e.g. "box:focused" to color the border of focused boxes) can style it
+// Unless either sets them, the border colors come from the theme
+type Component interface {
+	bubble_bath.InteractiveComponent
+	bubble_bath.FocusabilityReportingComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.LayoutContainerComponent
+	bubble_bath.DrawableComponent
+	bubble_bath.StylableComponent
+	bubble_bath.ThemedComponent
+
+	GetInner() bubble_bath.Component
+
+	// SetTitle sets the title shown in the top border (which is only shown if there is a top border)
+	SetTitle(title string)
+	GetTitle() string
+}
@@ -0,0 +1,125 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Topic is a named channel of events of type T on a Bus (e.g. "selection changed" or "document saved")
+// Topics are compared by identity rather than by name, so two topics created with the same name are still different
+// topics; create each one once (e.g. as a package variable) and share it between publishers & subscribers
+type Topic[T any] struct {
+	name string
+}
+
+func NewTopic[T any](name string) *Topic[T] {
+	return &Topic[T]{name: name}
+}
+
+func (topic *Topic[T]) GetName() string {
+	return topic.name
+}
+
+// PublishedMsg carries a published event to the Bus, which delivers it to the topic's subscribers
+type PublishedMsg struct {
+	// A *Topic[T], kept untyped so the message can carry any topic's events
+	topic any
+
+	event any
+}
+
+// Publish is a tea.Cmd factory that publishes the event to everything subscribed to the topic
+// The event only gets delivered if the program has a Bus (see WithBus)
+func Publish[T any](topic *Topic[T], event T) tea.Cmd {
+	return func() tea.Msg {
+		return PublishedMsg{
+			topic: topic,
+			event: event,
+		}
+	}
+}
+
+// Bus delivers published events to the subscribers of their topics, so that components that don't know about each
+// other (e.g. a list and a pane showing the details of its highlighted item) can react to each other without their
+// common parent wiring them together
+// Subscribers are called from the program's Update, so they can safely change component state
+type Bus struct {
+	subscriptionsByTopic map[any][]*busSubscription
+
+	nextSubscriptionID int
+}
+
+type busSubscription struct {
+	id int
+
+	// Takes the untyped event, which is always of the topic's type
+	handler func(event any) tea.Cmd
+}
+
+// Subscription is a handle for stopping a subscriber from getting any more events
+type Subscription struct {
+	bus   *Bus
+	topic any
+	id    int
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscriptionsByTopic: map[any][]*busSubscription{},
+		nextSubscriptionID:   0,
+	}
+}
+
+// Subscribe calls the handler with every event published to the topic (in the order the subscriptions were made),
+// until the returned subscription is cancelled
+// Components subscribing to a topic will usually want to subscribe in Mount and unsubscribe in Unmount (see Mounter)
+func Subscribe[T any](bus *Bus, topic *Topic[T], handler func(event T) tea.Cmd) Subscription {
+	id := bus.nextSubscriptionID
+	bus.nextSubscriptionID++
+
+	bus.subscriptionsByTopic[topic] = append(bus.subscriptionsByTopic[topic], &busSubscription{
+		id: id,
+		handler: func(event any) tea.Cmd {
+			return handler(event.(T))
+		},
+	})
+	return Subscription{
+		bus:   bus,
+		topic: topic,
+		id:    id,
+	}
+}
+
+// Unsubscribe stops the subscriber from getting any more events, and is a no-op if it's already been unsubscribed
+func (subscription Subscription) Unsubscribe() {
+	bus := subscription.bus
+	if bus == nil {
+		return
+	}
+
+	subscriptions := bus.subscriptionsByTopic[subscription.topic]
+	for idx, candidate := range subscriptions {
+		if candidate.id != subscription.id {
+			continue
+		}
+		// Copied rather than modified in place, in case the bus is in the middle of delivering to these subscriptions
+		remaining := make([]*busSubscription, 0, len(subscriptions)-1)
+		remaining = append(remaining, subscriptions[:idx]...)
+		remaining = append(remaining, subscriptions[idx+1:]...)
+		bus.subscriptionsByTopic[subscription.topic] = remaining
+		break
+	}
+	if len(bus.subscriptionsByTopic[subscription.topic]) == 0 {
+		delete(bus.subscriptionsByTopic, subscription.topic)
+	}
+}
+
+// Deliver calls the subscribers of the message's topic with its event, batching the commands they return
+// The program does this itself if it has the bus (see WithBus), so this is only needed when running without one
+func (bus *Bus) Deliver(msg PublishedMsg) tea.Cmd {
+	// The subscribers as of publishing get the event, even if one of them subscribes or unsubscribes something
+	subscriptions := bus.subscriptionsByTopic[msg.topic]
+
+	cmds := make([]tea.Cmd, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		cmds = append(cmds, subscription.handler(msg.event))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,294 @@
+package bubble_bath
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/ansi"
+)
+
+// Cell is a single terminal cell of a Canvas
+type Cell struct {
+	Content rune
+	Style   CellStyle
+
+	// Set on the cell to the right of a double-width character, which the character spills over into
+	isContinuation bool
+}
+
+var blankCell = Cell{
+	Content:        ' ',
+	Style:          CellStyle{},
+	isContinuation: false,
+}
+
+// cellBuffer is the grid of cells shared between a canvas and all of its sub-canvases
+type cellBuffer struct {
+	width  int
+	height int
+
+	// Row-major
+	cells []Cell
+}
+
+// Canvas is a grid of styled cells that components can draw into, as an alternative to rendering strings
+//
+// Each canvas is a window onto a region of a shared cell buffer: drawing coordinates are relative to the canvas'
+// top-left corner, and anything drawn outside the canvas is clipped, so a component can never draw outside the space
+// it was given
+// Strings are only produced once, when the whole buffer is serialized to ANSI
+type Canvas struct {
+	buffer *cellBuffer
+
+	// The region of the buffer that this canvas covers, which always lies within the buffer
+	region Rectangle
+}
+
+func NewCanvas(width int, height int) *Canvas {
+	width = GetMaxInt(0, width)
+	height = GetMaxInt(0, height)
+	buffer := &cellBuffer{
+		width:  width,
+		height: height,
+		cells:  make([]Cell, width*height),
+	}
+	for idx := range buffer.cells {
+		buffer.cells[idx] = blankCell
+	}
+	return &Canvas{
+		buffer: buffer,
+		region: Rectangle{X: 0, Y: 0, Width: width, Height: height},
+	}
+}
+
+func (canvas *Canvas) GetWidth() int {
+	return canvas.region.Width
+}
+
+func (canvas *Canvas) GetHeight() int {
+	return canvas.region.Height
+}
+
+// SubCanvas gets a canvas covering the given rectangle of this one (clipped to this one), sharing the same cells
+func (canvas *Canvas) SubCanvas(rectangle Rectangle) *Canvas {
+	left := Clamp(canvas.region.X+rectangle.X, canvas.region.X, canvas.region.X+canvas.region.Width)
+	top := Clamp(canvas.region.Y+rectangle.Y, canvas.region.Y, canvas.region.Y+canvas.region.Height)
+	right := Clamp(canvas.region.X+rectangle.X+rectangle.Width, left, canvas.region.X+canvas.region.Width)
+	bottom := Clamp(canvas.region.Y+rectangle.Y+rectangle.Height, top, canvas.region.Y+canvas.region.Height)
+	return &Canvas{
+		buffer: canvas.buffer,
+		region: Rectangle{X: left, Y: top, Width: right - left, Height: bottom - top},
+	}
+}
+
+// GetCell gets the cell at the given position, or a blank cell if the position is outside the canvas
+func (canvas *Canvas) GetCell(x int, y int) Cell {
+	if !canvas.isInBounds(x, y) {
+		return blankCell
+	}
+	return canvas.buffer.cells[canvas.getBufferIdx(x, y)]
+}
+
+// SetCell sets the cell at the given position (which is ignored if it's outside the canvas)
+// A double-width character that doesn't fit is replaced by a space, and a double-width character that gets partially
+// overwritten is erased
+func (canvas *Canvas) SetCell(x int, y int, content rune, style CellStyle) {
+	if !canvas.isInBounds(x, y) {
+		return
+	}
+
+	contentWidth := runewidth.RuneWidth(content)
+	if contentWidth == 2 && !canvas.isInBounds(x+1, y) {
+		content = ' '
+		contentWidth = 1
+	}
+
+	canvas.eraseWideCharacterAt(x, y)
+	canvas.buffer.cells[canvas.getBufferIdx(x, y)] = Cell{
+		Content:        content,
+		Style:          style,
+		isContinuation: false,
+	}
+	if contentWidth == 2 {
+		canvas.eraseWideCharacterAt(x+1, y)
+		canvas.buffer.cells[canvas.getBufferIdx(x+1, y)] = Cell{
+			Content:        ' ',
+			Style:          style,
+			isContinuation: true,
+		}
+	}
+}
+
+// Fill sets every cell in the rectangle (clipped to the canvas) to the given content & style
+func (canvas *Canvas) Fill(rectangle Rectangle, content rune, style CellStyle) {
+	for y := rectangle.Y; y < rectangle.Y+rectangle.Height; y++ {
+		for x := rectangle.X; x < rectangle.X+rectangle.Width; x += GetMaxInt(1, runewidth.RuneWidth(content)) {
+			canvas.SetCell(x, y, content, style)
+		}
+	}
+}
+
+// Clear blanks the whole canvas
+func (canvas *Canvas) Clear() {
+	canvas.Fill(Rectangle{X: 0, Y: 0, Width: canvas.region.Width, Height: canvas.region.Height}, ' ', CellStyle{})
+}
+
+// DrawString draws the (plain, single-line) string starting at the given position, returning the number of cells it
+// took up
+// Zero-width characters (e.g. combining marks) are skipped
+func (canvas *Canvas) DrawString(x int, y int, str string, style CellStyle) int {
+	cursor := x
+	for _, r := range str {
+		runeWidth := runewidth.RuneWidth(r)
+		if runeWidth == 0 {
+			continue
+		}
+		canvas.SetCell(cursor, y, r, style)
+		cursor += runeWidth
+	}
+	return cursor - x
+}
+
+// DrawView draws a rendered view (e.g. from a component's View) with its top-left corner at the given position,
+// translating its ANSI styling into cell styles
+// Cells that the view doesn't cover are left untouched
+func (canvas *Canvas) DrawView(x int, y int, view string) {
+	style := CellStyle{}
+	cursorX := x
+	cursorY := y
+
+	runes := []rune(view)
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		switch {
+		case r == ansi.Marker:
+			sequenceEndIdx := findEscapeSequenceEnd(runes, idx)
+			sequence := string(runes[idx:sequenceEndIdx])
+			if strings.HasPrefix(sequence, "\x1b[") && strings.HasSuffix(sequence, "m") {
+				style = style.applySGRParams(strings.TrimSuffix(strings.TrimPrefix(sequence, "\x1b["), "m"))
+			}
+			idx = sequenceEndIdx - 1
+		case r == '\n':
+			cursorX = x
+			cursorY++
+		case r == '\t':
+			cursorX += canvas.DrawString(cursorX, cursorY, " ", style)
+		default:
+			cursorX += canvas.DrawString(cursorX, cursorY, string(r), style)
+		}
+	}
+}
+
+// DrawCanvas copies the cells of another canvas with its top-left corner at the given position, which may be partly
+// (or entirely) outside this canvas
+// Unlike drawing into a SubCanvas, the source keeps its own coordinates, so e.g. a component sliding in from the left
+// shows its right side rather than getting shifted over
+func (canvas *Canvas) DrawCanvas(x int, y int, source *Canvas) {
+	for sourceY := 0; sourceY < source.region.Height; sourceY++ {
+		for sourceX := 0; sourceX < source.region.Width; sourceX++ {
+			cell := source.buffer.cells[source.getBufferIdx(sourceX, sourceY)]
+			if cell.isContinuation {
+				// Written along with the double-width character to its left
+				continue
+			}
+			canvas.SetCell(x+sourceX, y+sourceY, cell.Content, cell.Style)
+		}
+	}
+}
+
+// String serializes the canvas into lines of ANSI-styled text, as a View would return
+func (canvas *Canvas) String() string {
+	lines := make([]string, canvas.region.Height)
+	for y := 0; y < canvas.region.Height; y++ {
+		var line strings.Builder
+		currentStyle := CellStyle{}
+		for x := 0; x < canvas.region.Width; x++ {
+			cell := canvas.buffer.cells[canvas.getBufferIdx(x, y)]
+			if cell.isContinuation {
+				// A continuation cell at the canvas' left edge has had its character clipped off
+				if x == 0 {
+					line.WriteRune(' ')
+				}
+				continue
+			}
+			if cell.Style != currentStyle {
+				if currentStyle != (CellStyle{}) {
+					line.WriteString(ansiReset)
+				}
+				line.WriteString(cell.Style.getSGRSequence())
+				currentStyle = cell.Style
+			}
+			if x == canvas.region.Width-1 && runewidth.RuneWidth(cell.Content) == 2 {
+				// Likewise for a double-width character at the right edge
+				line.WriteRune(' ')
+				continue
+			}
+			line.WriteRune(cell.Content)
+		}
+		if currentStyle != (CellStyle{}) {
+			line.WriteString(ansiReset)
+		}
+		lines[y] = line.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+const ansiReset = "\x1b[0m"
+
+func (canvas *Canvas) isInBounds(x int, y int) bool {
+	return x >= 0 && x < canvas.region.Width && y >= 0 && y < canvas.region.Height
+}
+
+func (canvas *Canvas) getBufferIdx(x int, y int) int {
+	return (canvas.region.Y+y)*canvas.buffer.width + canvas.region.X + x
+}
+
+// eraseWideCharacterAt blanks the other half of any double-width character occupying the cell, so that overwriting
+// half of one doesn't leave the other half dangling
+// Works on buffer coordinates, since the other half may lie outside this canvas
+func (canvas *Canvas) eraseWideCharacterAt(x int, y int) {
+	bufferX := canvas.region.X + x
+	bufferY := canvas.region.Y + y
+	buffer := canvas.buffer
+	cell := buffer.cells[bufferY*buffer.width+bufferX]
+
+	if cell.isContinuation && bufferX > 0 {
+		buffer.cells[bufferY*buffer.width+bufferX-1] = Cell{Content: ' ', Style: cell.Style, isContinuation: false}
+	}
+	if !cell.isContinuation && runewidth.RuneWidth(cell.Content) == 2 && bufferX+1 < buffer.width {
+		buffer.cells[bufferY*buffer.width+bufferX+1] = Cell{Content: ' ', Style: cell.Style, isContinuation: false}
+	}
+}
+
+// findEscapeSequenceEnd gets the index just past the end of the escape sequence starting at the given index
+// Handles CSI sequences (e.g. colors) and OSC sequences (e.g. hyperlinks), which are the only ones views contain
+func findEscapeSequenceEnd(runes []rune, startIdx int) int {
+	if startIdx+1 >= len(runes) {
+		return len(runes)
+	}
+
+	switch runes[startIdx+1] {
+	case '[':
+		for idx := startIdx + 2; idx < len(runes); idx++ {
+			if runes[idx] >= '@' && runes[idx] <= '~' {
+				return idx + 1
+			}
+		}
+	case ']':
+		for idx := startIdx + 2; idx < len(runes); idx++ {
+			if runes[idx] == '\x07' {
+				return idx + 1
+			}
+			if runes[idx] == ansi.Marker && idx+1 < len(runes) && runes[idx+1] == '\\' {
+				return idx + 2
+			}
+		}
+	default:
+		return startIdx + 2
+	}
+	return len(runes)
+}
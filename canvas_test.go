@@ -0,0 +1,169 @@
+package bubble_bath
+
+import (
+	"testing"
+)
+
+func TestCanvasDrawView(t *testing.T) {
+	testCases := []struct {
+		name   string
+		width  int
+		height int
+		// If set, the canvas is filled with this before the view is drawn
+		background rune
+		x          int
+		y          int
+		view       string
+		expected   string
+	}{
+		{
+			name:     "plain lines",
+			width:    3,
+			height:   2,
+			view:     "ab\ncd",
+			expected: "ab \ncd ",
+		},
+		{
+			name:     "offset",
+			width:    3,
+			height:   2,
+			x:        1,
+			y:        1,
+			view:     "ab",
+			expected: "   \n ab",
+		},
+		{
+			name:     "clipped to the canvas",
+			width:    3,
+			height:   1,
+			x:        -1,
+			view:     "abcd",
+			expected: "bcd",
+		},
+		{
+			name:       "uncovered cells are left untouched",
+			width:      3,
+			height:     2,
+			background: '.',
+			view:       "ab\nc",
+			expected:   "ab.\nc..",
+		},
+		{
+			name:     "styles carry over to the cells",
+			width:    3,
+			height:   1,
+			view:     "\x1b[1;31mab\x1b[0mc",
+			expected: "\x1b[1;31mab\x1b[0mc",
+		},
+		{
+			name:     "hyperlinks are dropped",
+			width:    4,
+			height:   1,
+			view:     "\x1b]8;;https://example.com\x07link\x1b]8;;\x07",
+			expected: "link",
+		},
+		{
+			name:     "tabs become spaces",
+			width:    3,
+			height:   1,
+			view:     "a\tb",
+			expected: "a b",
+		},
+		{
+			name:     "wide characters that don't fit are replaced",
+			width:    3,
+			height:   1,
+			view:     "日本",
+			expected: "日 ",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := NewCanvas(testCase.width, testCase.height)
+			if testCase.background != 0 {
+				canvas.Fill(Rectangle{X: 0, Y: 0, Width: testCase.width, Height: testCase.height}, testCase.background, CellStyle{})
+			}
+			canvas.DrawView(testCase.x, testCase.y, testCase.view)
+			actual := canvas.String()
+			if actual != testCase.expected {
+				t.Errorf("Expected %q but got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCanvasString(t *testing.T) {
+	bold := CellStyle{Bold: true}
+	red := CellStyle{Foreground: "31"}
+
+	testCases := []struct {
+		name     string
+		draw     func(canvas *Canvas)
+		expected string
+	}{
+		{
+			name:     "blank",
+			draw:     func(canvas *Canvas) {},
+			expected: "   \n   ",
+		},
+		{
+			name: "runs of the same style share a sequence",
+			draw: func(canvas *Canvas) {
+				canvas.DrawString(0, 0, "ab", bold)
+				canvas.DrawString(2, 0, "c", red)
+			},
+			expected: "\x1b[1mab\x1b[0m\x1b[31mc\x1b[0m\n   ",
+		},
+		{
+			name: "sub-canvases draw relative to their own corner",
+			draw: func(canvas *Canvas) {
+				canvas.SubCanvas(Rectangle{X: 1, Y: 1, Width: 2, Height: 1}).DrawString(0, 0, "xyz", CellStyle{})
+			},
+			expected: "   \n xy",
+		},
+		{
+			name: "overwriting half of a wide character erases the other half",
+			draw: func(canvas *Canvas) {
+				canvas.DrawString(0, 0, "日", CellStyle{})
+				canvas.SetCell(1, 0, 'x', CellStyle{})
+			},
+			expected: " x \n   ",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := NewCanvas(3, 2)
+			testCase.draw(canvas)
+			actual := canvas.String()
+			if actual != testCase.expected {
+				t.Errorf("Expected %q but got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCanvasStringClipsWideCharactersAtEdges(t *testing.T) {
+	canvas := NewCanvas(4, 1)
+	canvas.DrawString(0, 0, "日本", CellStyle{})
+
+	// Each sub-canvas cuts one of the characters in half
+	testCases := []struct {
+		name      string
+		rectangle Rectangle
+		expected  string
+	}{
+		{name: "left edge", rectangle: Rectangle{X: 1, Y: 0, Width: 3, Height: 1}, expected: " 本"},
+		{name: "right edge", rectangle: Rectangle{X: 0, Y: 0, Width: 3, Height: 1}, expected: "日 "},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.SubCanvas(testCase.rectangle).String()
+			if actual != testCase.expected {
+				t.Errorf("Expected %q but got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package bubble_bath
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CellStyle is the styling of a single terminal cell
+// It's comparable, so that runs of identically-styled cells can be serialized together
+type CellStyle struct {
+	// The SGR parameters that set the colors (e.g. "31", "38;5;232", or "38;2;255;0;0"), or empty for the terminal's
+	// default
+	Foreground string
+	Background string
+
+	Bold          bool
+	Faint         bool
+	Italic        bool
+	Underline     bool
+	Blink         bool
+	Reverse       bool
+	Strikethrough bool
+}
+
+// NewCellStyle gets the cell styling that the lipgloss style would produce under the current color profile
+// Only the styling that applies to individual cells is kept; layout properties like padding & borders are ignored
+func NewCellStyle(style lipgloss.Style) CellStyle {
+	// Lipgloss doesn't expose how its colors get rendered, so we render a probe & read the style back out
+	// Inline rendering skips the margins, padding, and borders
+	probeStyle := style.Copy().
+		Inline(true).
+		UnsetWidth().
+		UnsetHeight().
+		UnsetMaxWidth().
+		UnsetMaxHeight()
+	probe := NewCanvas(1, 1)
+	probe.DrawView(0, 0, probeStyle.Render("x"))
+	return probe.GetCell(0, 0).Style
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// getSGRSequence gets the escape sequence that switches the terminal to this style from the default one
+func (style CellStyle) getSGRSequence() string {
+	params := make([]string, 0)
+	if style.Bold {
+		params = append(params, "1")
+	}
+	if style.Faint {
+		params = append(params, "2")
+	}
+	if style.Italic {
+		params = append(params, "3")
+	}
+	if style.Underline {
+		params = append(params, "4")
+	}
+	if style.Blink {
+		params = append(params, "5")
+	}
+	if style.Reverse {
+		params = append(params, "7")
+	}
+	if style.Strikethrough {
+		params = append(params, "9")
+	}
+	if style.Foreground != "" {
+		params = append(params, style.Foreground)
+	}
+	if style.Background != "" {
+		params = append(params, style.Background)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// applySGRParams updates the style according to the parameters of an SGR ("Select Graphic Rendition") escape sequence
+// Parameters that don't map to a cell style (e.g. fonts) are ignored
+func (style CellStyle) applySGRParams(paramsStr string) CellStyle {
+	if paramsStr == "" {
+		return CellStyle{}
+	}
+
+	params := strings.Split(paramsStr, ";")
+	for idx := 0; idx < len(params); idx++ {
+		param, err := strconv.Atoi(params[idx])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case param == 0:
+			style = CellStyle{}
+		case param == 1:
+			style.Bold = true
+		case param == 2:
+			style.Faint = true
+		case param == 3:
+			style.Italic = true
+		case param == 4:
+			style.Underline = true
+		case param == 5:
+			style.Blink = true
+		case param == 7:
+			style.Reverse = true
+		case param == 9:
+			style.Strikethrough = true
+		case param == 22:
+			style.Bold = false
+			style.Faint = false
+		case param == 23:
+			style.Italic = false
+		case param == 24:
+			style.Underline = false
+		case param == 25:
+			style.Blink = false
+		case param == 27:
+			style.Reverse = false
+		case param == 29:
+			style.Strikethrough = false
+		case (param >= 30 && param <= 37) || (param >= 90 && param <= 97):
+			style.Foreground = params[idx]
+		case param == 39:
+			style.Foreground = ""
+		case (param >= 40 && param <= 47) || (param >= 100 && param <= 107):
+			style.Background = params[idx]
+		case param == 49:
+			style.Background = ""
+		case param == 38 || param == 48:
+			// Extended colors take their arguments from the following parameters
+			numArgs := 0
+			if idx+1 < len(params) && params[idx+1] == "5" {
+				numArgs = 2
+			} else if idx+1 < len(params) && params[idx+1] == "2" {
+				numArgs = 4
+			}
+			if numArgs == 0 || idx+numArgs >= len(params) {
+				idx = len(params)
+				break
+			}
+			color := strings.Join(params[idx:idx+numArgs+1], ";")
+			if param == 38 {
+				style.Foreground = color
+			} else {
+				style.Background = color
+			}
+			idx += numArgs
+		}
+	}
+	return style
+}
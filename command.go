@@ -0,0 +1,60 @@
+package bubble_bath
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Command is an action that the user can run by name (e.g. from the command palette)
+type Command struct {
+	Name        string
+	Description string
+
+	// The binding that also triggers the action, shown alongside the command so users can learn it
+	// Can be left empty if there isn't one
+	KeyBinding key.Binding
+
+	// Dispatched when the command is chosen
+	Cmd tea.Cmd
+}
+
+// CommandProvider is a component that offers commands that only make sense while it's focused (e.g. a list offering
+// "Select all")
+type CommandProvider interface {
+	Component
+
+	GetCommands() []Command
+}
+
+// CommandRegistry collects the commands that are available right now: those of the CommandProviders on the focused
+// path through the component tree (innermost first), followed by the app-wide commands added to it
+// The focused path is re-walked on every call, so the commands follow focus as it moves
+type CommandRegistry struct {
+	root Component
+
+	globalCommands []Command
+}
+
+func NewCommandRegistry(root Component) *CommandRegistry {
+	return &CommandRegistry{
+		root:           root,
+		globalCommands: make([]Command, 0),
+	}
+}
+
+// AddGlobalCommands adds app-wide commands that are available regardless of focus
+func (registry *CommandRegistry) AddGlobalCommands(commands ...Command) {
+	registry.globalCommands = append(registry.globalCommands, commands...)
+}
+
+// GetCommands gets the commands of each focused provider, followed by the app-wide commands
+func (registry *CommandRegistry) GetCommands() []Command {
+	results := make([]Command, 0)
+	for _, component := range getFocusedPathComponents(registry.root) {
+		if provider, ok := component.(CommandProvider); ok {
+			results = append(results, provider.GetCommands()...)
+		}
+	}
+	results = append(results, registry.globalCommands...)
+	return results
+}
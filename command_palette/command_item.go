@@ -0,0 +1,122 @@
+package command_palette
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/muesli/reflow/truncate"
+)
+
+// Space between the name, description, and key binding
+const commandItemColumnGap = 2
+
+// ItemStyleType is the name that style sheet type selectors use to match the palette's commands
+// The highlighted command is in the bubble_bath.StyleStateHighlighted state
+const ItemStyleType = "command_palette_item"
+
+// commandItem is a line of the palette's list: the command's name, then its description, with its key binding on the
+// right
+type commandItem struct {
+	bubble_bath.StyleNode
+
+	command bubble_bath.Command
+
+	theme bubble_bath.Theme
+
+	isHighlighted bool
+	width         int
+	height        int
+}
+
+func newCommandItem(command bubble_bath.Command, theme bubble_bath.Theme) *commandItem {
+	return &commandItem{
+		StyleNode:     bubble_bath.StyleNode{},
+		command:       command,
+		theme:         theme,
+		isHighlighted: false,
+		width:         0,
+		height:        0,
+	}
+}
+
+func (item *commandItem) View() string {
+	// Inline, since the row is laid out here rather than by the style's margins, borders, and padding
+	baseStyle := item.GetStyle().ToLipgloss().Inline(true)
+	nameStyle := baseStyle.Copy().Foreground(item.theme.Foreground).Bold(true)
+	mutedStyle := baseStyle.Copy().Foreground(item.theme.Muted)
+
+	// The key binding is dropped first when space is tight, then the description
+	keyStr := item.getKeyString()
+	keyWidth := runewidth.StringWidth(keyStr)
+	if keyWidth+commandItemColumnGap > item.width/2 {
+		keyStr = ""
+		keyWidth = 0
+	}
+	leftWidth := item.width
+	if keyWidth > 0 {
+		leftWidth -= keyWidth + commandItemColumnGap
+	}
+
+	name := truncate.StringWithTail(item.command.Name, uint(bubble_bath.GetMaxInt(0, leftWidth)), "…")
+	usedWidth := runewidth.StringWidth(name)
+	result := nameStyle.Render(name)
+
+	descriptionWidth := leftWidth - usedWidth - commandItemColumnGap
+	if item.command.Description != "" && descriptionWidth > 0 {
+		description := truncate.StringWithTail(item.command.Description, uint(descriptionWidth), "…")
+		result += baseStyle.Render(strings.Repeat(" ", commandItemColumnGap)) + mutedStyle.Render(description)
+		usedWidth += commandItemColumnGap + runewidth.StringWidth(description)
+	}
+
+	padding := bubble_bath.GetMaxInt(0, item.width-usedWidth-keyWidth)
+	result += baseStyle.Render(strings.Repeat(" ", padding))
+	if keyWidth > 0 {
+		result += mutedStyle.Render(keyStr)
+	}
+	return result
+}
+
+func (item *commandItem) Resize(width int, height int) {
+	item.width = width
+	item.height = height
+}
+
+func (item *commandItem) GetWidth() int {
+	return item.width
+}
+
+func (item *commandItem) GetHeight() int {
+	return item.height
+}
+
+func (item *commandItem) IsHighlighted() bool {
+	return item.isHighlighted
+}
+
+func (item *commandItem) SetHighlighted(isHighlighted bool) {
+	item.isHighlighted = isHighlighted
+}
+
+func (item *commandItem) GetValue() string {
+	return item.command.Name
+}
+
+func (item *commandItem) GetStyleType() string {
+	return ItemStyleType
+}
+
+func (item *commandItem) HasStyleState(state bubble_bath.StyleState) bool {
+	return state == bubble_bath.StyleStateHighlighted && item.isHighlighted
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (item *commandItem) getKeyString() string {
+	if !item.command.KeyBinding.Enabled() {
+		return ""
+	}
+	return item.command.KeyBinding.Help().Key
+}
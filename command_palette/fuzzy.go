@@ -0,0 +1,61 @@
+package command_palette
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	consecutiveMatchBonus = 5
+	wordStartMatchBonus   = 3
+)
+
+// fuzzyMatch checks whether the runes of the pattern all appear in order in the candidate (ignoring case), scoring
+// the match higher the more of the matched runes are consecutive or start words (so "ct" scores "Change theme" above
+// "Cut text")
+func fuzzyMatch(pattern string, candidate string) (int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	candidateRunes := []rune(candidate)
+	if len(patternRunes) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	patternIdx := 0
+	lastMatchIdx := -2
+	for candidateIdx, candidateRune := range candidateRunes {
+		if patternIdx == len(patternRunes) {
+			break
+		}
+		if unicode.ToLower(candidateRune) != patternRunes[patternIdx] {
+			continue
+		}
+
+		score++
+		if candidateIdx == lastMatchIdx+1 {
+			score += consecutiveMatchBonus
+		}
+		if isWordStart(candidateRunes, candidateIdx) {
+			score += wordStartMatchBonus
+		}
+		lastMatchIdx = candidateIdx
+		patternIdx++
+	}
+
+	if patternIdx < len(patternRunes) {
+		return 0, false
+	}
+	return score, true
+}
+
+func isWordStart(runes []rune, idx int) bool {
+	if idx == 0 {
+		return true
+	}
+	previous := runes[idx-1]
+	current := runes[idx]
+	if !unicode.IsLetter(previous) && !unicode.IsDigit(previous) {
+		return true
+	}
+	return unicode.IsLower(previous) && unicode.IsUpper(current)
+}
@@ -0,0 +1,336 @@
+package command_palette
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/box"
+	"github.com/mieubrisse/bubble-bath/filterable_list"
+	"github.com/mieubrisse/bubble-bath/text_input"
+)
+
+const (
+	defaultWidth                = 60
+	defaultMaxDisplayedCommands = 10
+
+	// Matches on the description count for less than matches on the name
+	descriptionMatchPenalty = 100
+
+	noMatchesText = "No matching commands"
+)
+
+type PaletteOption func(*implementation)
+
+// WithWidth sets the width of the palette's overlay, including its border
+func WithWidth(width int) PaletteOption {
+	return func(impl *implementation) {
+		impl.overlayWidth = width
+	}
+}
+
+// WithMaxDisplayedCommands sets how many commands are shown at once, which sets the palette's height
+func WithMaxDisplayedCommands(maxDisplayedCommands int) PaletteOption {
+	return func(impl *implementation) {
+		impl.maxDisplayedCommands = maxDisplayedCommands
+	}
+}
+
+// WithPrompt sets the prompt shown before the search text
+func WithPrompt(prompt string) PaletteOption {
+	return func(impl *implementation) {
+		impl.prompt = prompt
+	}
+}
+
+type implementation struct {
+	registry *bubble_bath.CommandRegistry
+
+	// The box that the palette opens in, which is what actually gets pushed onto the overlay stack
+	frame box.Component
+
+	input text_input.Model
+	list  *commandList
+
+	// The commands available as of when the palette was opened, in registry order
+	items []*commandItem
+
+	// The search text that the list was last filtered by
+	lastQuery string
+
+	keyMap KeyMap
+	theme  bubble_bath.Theme
+
+	prompt               string
+	overlayWidth         int
+	maxDisplayedCommands int
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+// New creates a palette over the registry's commands
+func New(registry *bubble_bath.CommandRegistry, opts ...PaletteOption) Component {
+	result := &implementation{
+		registry:             registry,
+		frame:                nil,
+		input:                text_input.Model{},
+		list:                 &commandList{Component: filterable_list.New[*commandItem]()},
+		items:                make([]*commandItem, 0),
+		lastQuery:            "",
+		keyMap:               DefaultKeyMap,
+		theme:                bubble_bath.DarkTheme,
+		prompt:               "> ",
+		overlayWidth:         defaultWidth,
+		maxDisplayedCommands: defaultMaxDisplayedCommands,
+		isFocused:            false,
+		width:                0,
+		height:               0,
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	result.input = text_input.New(result.prompt)
+	result.input.SetTheme(result.theme)
+	result.frame = box.New(
+		result,
+		box.WithBorder(lipgloss.RoundedBorder()),
+		box.WithPadding(0, 1),
+		box.WithTitle("Commands"),
+	)
+	return result
+}
+
+func (impl *implementation) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if impl.isFocused || !key.Matches(msg, impl.keyMap.Open) {
+		return nil, false
+	}
+	return impl.Open(), true
+}
+
+func (impl *implementation) Open() tea.Cmd {
+	commands := impl.registry.GetCommands()
+	impl.items = make([]*commandItem, len(commands))
+	for idx, command := range commands {
+		impl.items[idx] = newCommandItem(command, impl.theme)
+	}
+
+	impl.input.SetValue("")
+	impl.refilter()
+
+	return bubble_bath.PushOverlay(bubble_bath.Overlay{
+		Component: impl.frame,
+		Position:  bubble_bath.OverlayCentered(),
+		Width:     impl.overlayWidth,
+		Height:    0,
+	})
+}
+
+func (impl *implementation) Close() tea.Cmd {
+	return bubble_bath.PopOverlay
+}
+
+func (impl *implementation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case bubble_bath.ThemeChangedMsg:
+		impl.SetTheme(msg.Current)
+		return nil
+	case tea.KeyMsg:
+		cmd, _ := impl.CaptureEvent(msg)
+		return cmd
+	}
+
+	// E.g. the cursor blinking
+	return impl.input.Update(msg)
+}
+
+// CaptureEvent handles every key while the palette is open, since it's modal
+// Capturing (rather than handling as the event bubbles) keeps the search input from taking typed text before the
+// palette gets to refilter on it
+func (impl *implementation) CaptureEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !impl.isFocused {
+		return nil, false
+	}
+	return impl.handleKeyMsg(keyMsg), true
+}
+
+func (impl *implementation) View() string {
+	inputView := impl.input.View()
+
+	listView := impl.list.View()
+	if len(impl.list.GetFilteredItemIndices()) == 0 {
+		listView = lipgloss.NewStyle().Foreground(impl.theme.Muted).Render(noMatchesText)
+	}
+
+	return lipgloss.NewStyle().
+		Width(impl.width).
+		Height(impl.height).
+		MaxWidth(impl.width).
+		MaxHeight(impl.height).
+		Render(lipgloss.JoinVertical(lipgloss.Left, inputView, listView))
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+
+	inputHeight := bubble_bath.GetMinInt(1, height)
+	impl.input.Resize(width, inputHeight)
+	impl.list.Resize(width, bubble_bath.GetMaxInt(0, height-inputHeight))
+}
+
+func (impl *implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl *implementation) GetHeight() int {
+	return impl.height
+}
+
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	return impl.input.GetMinimumIntrinsicWidth()
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return impl.overlayWidth
+}
+
+// GetHeightGivenWidth always leaves room for the maximum number of commands, so that the palette doesn't jump around
+// as the search narrows
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	return 1 + impl.maxDisplayedCommands
+}
+
+// GetChildren gets the search input & the list, so that they get styled & have the key map config applied like any
+// other component
+func (impl *implementation) GetChildren() []bubble_bath.Component {
+	return []bubble_bath.Component{&impl.input, impl.list}
+}
+
+func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
+	impl.isFocused = isFocused
+
+	// The list is left unfocused, since the palette scrolls it itself (and otherwise its bindings would show in help)
+	return impl.input.SetFocus(isFocused)
+}
+
+func (impl *implementation) IsFocused() bool {
+	return impl.isFocused
+}
+
+func (impl *implementation) SetTheme(theme bubble_bath.Theme) {
+	if theme == impl.theme {
+		return
+	}
+	impl.theme = theme
+	impl.input.SetTheme(theme)
+	for _, item := range impl.items {
+		item.theme = theme
+	}
+}
+
+func (impl *implementation) GetKeyMap() KeyMap {
+	return impl.keyMap
+}
+
+func (impl *implementation) SetKeyMap(keyMap KeyMap) {
+	impl.keyMap = keyMap
+}
+
+func (impl *implementation) ApplyKeyMapConfig(config *bubble_bath.KeyMapConfig) {
+	config.Apply(KeyMapType, &impl.keyMap)
+}
+
+func (impl *implementation) ShortHelp() []key.Binding {
+	return impl.keyMap.ShortHelp()
+}
+
+func (impl *implementation) FullHelp() [][]key.Binding {
+	return impl.keyMap.FullHelp()
+}
+
+// commandList is the palette's list, which the focus manager shouldn't stop on since the palette drives it from the
+// keys typed into the search input
+type commandList struct {
+	filterable_list.Component[*commandItem]
+}
+
+func (list *commandList) IsFocusable() bool {
+	return false
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (impl *implementation) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, impl.keyMap.Close):
+		return impl.Close()
+	case key.Matches(msg, impl.keyMap.Run):
+		return impl.runHighlightedCommand()
+	case key.Matches(msg, impl.keyMap.Next):
+		impl.list.Scroll(1)
+		return nil
+	case key.Matches(msg, impl.keyMap.Previous):
+		impl.list.Scroll(-1)
+		return nil
+	}
+
+	cmd := impl.input.Update(msg)
+	if impl.input.GetValue() != impl.lastQuery {
+		impl.refilter()
+	}
+	return cmd
+}
+
+// runHighlightedCommand closes the palette before dispatching the command, so that focus is back where it was by the
+// time the command runs
+func (impl *implementation) runHighlightedCommand() tea.Cmd {
+	filteredIndices := impl.list.GetFilteredItemIndices()
+	if len(filteredIndices) == 0 {
+		return nil
+	}
+	command := impl.list.GetItems()[filteredIndices[impl.list.GetHighlightedItemIndex()]].command
+	return tea.Sequence(impl.Close(), command.Cmd)
+}
+
+// refilter shows only the commands matching the search text, best matches first
+func (impl *implementation) refilter() {
+	query := strings.TrimSpace(impl.input.GetValue())
+	impl.lastQuery = impl.input.GetValue()
+
+	type scoredItem struct {
+		item  *commandItem
+		score int
+	}
+	matches := make([]scoredItem, 0, len(impl.items))
+	for _, item := range impl.items {
+		item.SetHighlighted(false)
+		if score, found := fuzzyMatch(query, item.command.Name); found {
+			matches = append(matches, scoredItem{item: item, score: score})
+		} else if score, found := fuzzyMatch(query, item.command.Description); found {
+			matches = append(matches, scoredItem{item: item, score: score - descriptionMatchPenalty})
+		}
+	}
+
+	// Stable so that equally-good matches stay in registry order (which puts the focused component's commands first)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	matchingItems := make([]*commandItem, len(matches))
+	for idx, match := range matches {
+		matchingItems[idx] = match.item
+	}
+	impl.list.SetItems(matchingItems)
+
+	// The list only resizes its items when it's resized itself
+	impl.list.Resize(impl.list.GetWidth(), impl.list.GetHeight())
+}
@@ -0,0 +1,35 @@
+package command_palette
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// Component is a searchable list of the commands in a bubble_bath.CommandRegistry, which opens as an overlay (so there
+// must be a bubble_bath.OverlayStack above wherever the hotkey is handled)
+// Typing fuzzy-filters the commands, and choosing one closes the palette & dispatches the command's tea.Cmd
+type Component interface {
+	bubble_bath.InteractiveComponent
+	bubble_bath.ContainerComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.ThemedComponent
+	bubble_bath.KeyBindingProvider
+	bubble_bath.KeyRemappableComponent
+	bubble_bath.EventCapturer
+
+	// HandleKey opens the palette if the key matches the Open binding and the palette isn't already open, returning
+	// true if it did
+	// The palette is only in the tree while it's open, so whatever calls this should pass its key map config along to
+	// the palette with ApplyKeyMapConfig when it's mounted (otherwise a remapped Open binding won't take effect)
+	HandleKey(msg tea.KeyMsg) (tea.Cmd, bool)
+
+	// Open gathers the commands that are available right now (so it needs to be called before focus moves to the
+	// palette) and opens the palette
+	Open() tea.Cmd
+
+	// Close closes the palette without running anything
+	Close() tea.Cmd
+
+	GetKeyMap() KeyMap
+	SetKeyMap(keyMap KeyMap)
+}
@@ -0,0 +1,54 @@
+package command_palette
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// KeyMapType is the name that key map config files use for the palette's bindings
+const KeyMapType = "command_palette"
+
+type KeyMap struct {
+	// Handled by HandleKey, since the palette isn't in the tree until it's open (which is also why help views need to
+	// get it from the palette's GetKeyMap rather than from ShortHelp)
+	Open key.Binding
+
+	Run      key.Binding
+	Close    key.Binding
+	Next     key.Binding
+	Previous key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	Open:     key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "commands")),
+	Run:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "run")),
+	Close:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+	Next:     key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("down", "next")),
+	Previous: key.NewBinding(key.WithKeys("up"), key.WithHelp("up", "previous")),
+}
+
+// ShortHelp gets the bindings used while the palette is open
+func (keyMap KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keyMap.Run, keyMap.Close, keyMap.Next, keyMap.Previous}
+}
+
+func (keyMap KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{keyMap.Run, keyMap.Close},
+		{keyMap.Next, keyMap.Previous},
+	}
+}
+
+func (keyMap *KeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"open":     &keyMap.Open,
+		"run":      &keyMap.Run,
+		"close":    &keyMap.Close,
+		"next":     &keyMap.Next,
+		"previous": &keyMap.Previous,
+	}
+}
+
+func init() {
+	bubble_bath.RegisterKeyMapType(KeyMapType, &DefaultKeyMap)
+}
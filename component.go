@@ -1,5 +1,14 @@
 package bubble_bath
 
+import (
+	"fmt"
+	"reflect"
+)
+
+// Component is anything that can be shown in the component tree
+// Components must be pointers (e.g. a New function returning a pointer to its implementation), since the framework
+// identifies them by pointer identity: it uses them as map keys and compares them (e.g. to track focus & layout), which
+// would panic for value types holding slices or maps, and would mistake copies of any other value type for each other
 type Component interface {
 	View() string
 
@@ -10,3 +19,15 @@ type Component interface {
 	GetWidth() int
 	GetHeight() int
 }
+
+// mustBePointer enforces that the component is a pointer (see Component), panicking with an explanation if it isn't so
+// that the mistake surfaces when the component enters the tree rather than as a confusing panic somewhere later
+func mustBePointer(component Component) {
+	if component == nil || reflect.TypeOf(component).Kind() == reflect.Pointer {
+		return
+	}
+	panic(fmt.Sprintf(
+		"Component of type '%T' isn't a pointer; components must be pointers since they're identified by pointer identity",
+		component,
+	))
+}
@@ -0,0 +1,42 @@
+package bubble_bath
+
+import (
+	"strings"
+	"testing"
+)
+
+// valueComponent is a component that's mistakenly a value type, holding a slice so it isn't even comparable
+type valueComponent struct {
+	lines []string
+}
+
+func (component valueComponent) View() string {
+	return strings.Join(component.lines, "\n")
+}
+
+func (component valueComponent) Resize(width int, height int) {}
+
+func (component valueComponent) GetWidth() int {
+	return 0
+}
+
+func (component valueComponent) GetHeight() int {
+	return 0
+}
+
+func TestMountTreeRejectsValueComponents(t *testing.T) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			t.Fatal("Expected mounting a value component to panic, but it didn't")
+		}
+		if !strings.Contains(recovered.(string), "valueComponent") {
+			t.Errorf("Expected the panic to name the offending type, but got: %v", recovered)
+		}
+	}()
+	MountTree(valueComponent{lines: []string{"a", "b"}}, MountContext{KeyMapConfig: nil, styleTracker: nil})
+}
+
+func TestMountTreeAcceptsPointerComponents(t *testing.T) {
+	MountTree(&valueComponent{lines: []string{"a", "b"}}, MountContext{KeyMapConfig: nil, styleTracker: nil})
+}
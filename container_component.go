@@ -0,0 +1,106 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ContainerComponent is implemented by components that hold other components, so that framework-level features (e.g.
+// focus management) can walk the component tree
+type ContainerComponent interface {
+	Component
+
+	// GetChildren gets the container's direct children, in the order that focus should traverse them
+	GetChildren() []Component
+}
+
+// FocusRoutingContainerComponent is a container that can be told which of its children should be focused (and therefore
+// receive events) when the container itself is focused
+type FocusRoutingContainerComponent interface {
+	ContainerComponent
+
+	// SetFocusReceivingChildren indicates which children (by index within GetChildren) should be focused when the
+	// container is focused, returning the commands from focusing & unfocusing the children if the container is focused
+	SetFocusReceivingChildren(focusReceivingChildrenIndexes map[int]bool) tea.Cmd
+}
+
+// LayoutContainerComponent is a container that can report where each of its children sits within it, as of the last
+// Resize
+type LayoutContainerComponent interface {
+	ContainerComponent
+
+	// GetChildRectangles gets the rectangle of each child (by index within GetChildren), relative to the container's
+	// top-left corner
+	GetChildRectangles() []Rectangle
+}
+
+// GetChildren gets the children of the component if it's a ContainerComponent, or nothing otherwise
+func GetChildren(component Component) []Component {
+	container, ok := component.(ContainerComponent)
+	if !ok {
+		return nil
+	}
+	return container.GetChildren()
+}
+
+// getFocusedPathComponents gets the components on the focused path(s) through the tree, with descendants before their
+// ancestors (so the most specific come first)
+// The root is always considered focused, since it's where every message starts
+func getFocusedPathComponents(root Component) []Component {
+	results := make([]Component, 0)
+	collectFocusedPathComponents(root, &results)
+	return results
+}
+
+func collectFocusedPathComponents(component Component, results *[]Component) {
+	for _, child := range GetChildren(component) {
+		interactiveChild, ok := child.(InteractiveComponent)
+		if !ok || !interactiveChild.IsFocused() {
+			continue
+		}
+		collectFocusedPathComponents(child, results)
+	}
+	*results = append(*results, component)
+}
+
+// GetAbsoluteRectangles gets the rectangle of every component in the tree relative to the root's top-left corner, using
+// the rectangles reported by LayoutContainerComponents
+// Children of containers that don't report their layout are assumed to sit at their container's top-left corner
+func GetAbsoluteRectangles(root Component) map[Component]Rectangle {
+	results := map[Component]Rectangle{}
+	rootRectangle := Rectangle{
+		X:      0,
+		Y:      0,
+		Width:  root.GetWidth(),
+		Height: root.GetHeight(),
+	}
+	collectAbsoluteRectangles(root, rootRectangle, results)
+	return results
+}
+
+func collectAbsoluteRectangles(component Component, absoluteRectangle Rectangle, results map[Component]Rectangle) {
+	mustBePointer(component)
+	results[component] = absoluteRectangle
+
+	children := GetChildren(component)
+	var childRectangles []Rectangle
+	if layoutContainer, ok := component.(LayoutContainerComponent); ok {
+		childRectangles = layoutContainer.GetChildRectangles()
+	}
+
+	for idx, child := range children {
+		relativeRectangle := Rectangle{
+			X:      0,
+			Y:      0,
+			Width:  child.GetWidth(),
+			Height: child.GetHeight(),
+		}
+		if idx < len(childRectangles) {
+			relativeRectangle = childRectangles[idx]
+		}
+
+		collectAbsoluteRectangles(child, Rectangle{
+			X:      absoluteRectangle.X + relativeRectangle.X,
+			Y:      absoluteRectangle.Y + relativeRectangle.Y,
+			Width:  relativeRectangle.Width,
+			Height: relativeRectangle.Height,
+		}, results)
+	}
+}
@@ -9,11 +9,35 @@ import (
 )
 
 func main() {
+	app := my_app.New()
 	if _, err := bubble_bath.RunBubbleBathProgram(
-		my_app.New(),
-		nil,
+		app,
+		[]bubble_bath.BubbleBathOption{
+			// Tab & Shift-Tab will move between the lists
+			bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap),
+
+			// Clicking a list will focus it & highlight the clicked item, and the wheel will scroll it
+			bubble_bath.WithMouseRouting(),
+
+			// Lets the help at the bottom show the quit & focus bindings too
+			bubble_bath.WithKeyBindingRegistry(app.GetKeyBindingRegistry()),
+
+			// Lets the status line follow the lists' highlights
+			bubble_bath.WithBus(app.GetBus()),
+
+			// The theme is picked based on the terminal's background, and the default style sheet's colors follow it
+			bubble_bath.WithThemedStyleSheet(func(theme bubble_bath.Theme) *bubble_bath.StyleSheet {
+				return bubble_bath.NewDefaultStyleSheet(theme).MustExtend(
+					bubble_bath.StyleRule{
+						Selector: ".title",
+						Style:    bubble_bath.NewStyle().Bold(true),
+					},
+				)
+			}),
+		},
 		[]tea.ProgramOption{
 			tea.WithAltScreen(),
+			tea.WithMouseCellMotion(),
 		},
 	); err != nil {
 		fmt.Printf("An error occurred running the program:\n%v", err)
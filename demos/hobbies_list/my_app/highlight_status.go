@@ -0,0 +1,80 @@
+package my_app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/filterable_list"
+	"github.com/mieubrisse/bubble-bath/filterable_list_item"
+)
+
+// Both lists publish to this when their highlight moves
+var highlightChangedTopic = bubble_bath.NewTopic[filterable_list.HighlightChangedEvent[filterable_list_item.Component]](
+	"highlight changed",
+)
+
+// highlightStatus shows the most recently highlighted item, finding out about it over the bus rather than from the app
+type highlightStatus struct {
+	bus          *bubble_bath.Bus
+	subscription bubble_bath.Subscription
+
+	// Empty until something gets highlighted
+	highlightedValue string
+
+	width  int
+	height int
+}
+
+func newHighlightStatus(bus *bubble_bath.Bus) *highlightStatus {
+	return &highlightStatus{
+		bus:              bus,
+		subscription:     bubble_bath.Subscription{},
+		highlightedValue: "",
+		width:            0,
+		height:           0,
+	}
+}
+
+func (status *highlightStatus) Mount(ctx bubble_bath.MountContext) tea.Cmd {
+	status.subscription = bubble_bath.Subscribe(
+		status.bus,
+		highlightChangedTopic,
+		func(event filterable_list.HighlightChangedEvent[filterable_list_item.Component]) tea.Cmd {
+			status.highlightedValue = event.Item.GetValue()
+			return nil
+		},
+	)
+	return nil
+}
+
+func (status *highlightStatus) Unmount() tea.Cmd {
+	status.subscription.Unsubscribe()
+	return nil
+}
+
+func (status *highlightStatus) View() string {
+	text := "Move through a list to see its highlighted item here"
+	if status.highlightedValue != "" {
+		text = "Last highlighted: " + status.highlightedValue
+	}
+	return lipgloss.NewStyle().
+		Italic(true).
+		Width(status.width).
+		Height(status.height).
+		MaxWidth(status.width).
+		MaxHeight(status.height).
+		Render(text)
+}
+
+func (status *highlightStatus) Resize(width int, height int) {
+	status.width = width
+	status.height = height
+}
+
+func (status *highlightStatus) GetWidth() int {
+	return status.width
+}
+
+func (status *highlightStatus) GetHeight() int {
+	return status.height
+}
@@ -1,24 +1,49 @@
 package my_app
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/command_palette"
 	"github.com/mieubrisse/bubble-bath/filterable_list"
 	"github.com/mieubrisse/bubble-bath/filterable_list_item"
 	"github.com/mieubrisse/bubble-bath/flexbox"
+	"github.com/mieubrisse/bubble-bath/help"
 	"github.com/mieubrisse/bubble-bath/text_block"
 )
 
+var toggleThemeBinding = key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "toggle theme"))
+
+// Sent by both the hotkeys & the command palette's commands
+type toggleThemeMsg struct{}
+type toggleFullHelpMsg struct{}
+
 type implementation struct {
 	hobbiesAndTitle flexbox.Component
 
+	// Lets the command palette open over everything else
+	overlayStack *bubble_bath.OverlayStack
+
+	keyBindingRegistry *bubble_bath.KeyBindingRegistry
+	help               help.Component
+
+	commandRegistry *bubble_bath.CommandRegistry
+	commandPalette  command_palette.Component
+
+	// Lets the lists tell the highlight status about their highlights without going through the app
+	bus *bubble_bath.Bus
+
+	// Kept up-to-date by the program (see SetTheme), so that Ctrl+T knows which theme to toggle to
+	theme bubble_bath.Theme
+
 	width  int
 	height int
 }
 
 func New() MyApp {
-	hobbiesListTitleStyle := lipgloss.NewStyle().Bold(true)
-	hobbiesListTitle := text_block.New("My hobbies:", text_block.WithStyle(hobbiesListTitleStyle))
+	// Styled by the ".title" rule in the app's style sheet
+	hobbiesListTitle := text_block.New("My hobbies:")
+	hobbiesListTitle.SetClasses("title")
 
 	hobbies := []filterable_list_item.Component{
 		filterable_list_item.New(text_block.New("Pourover coffee"), "coffee"),
@@ -27,10 +52,67 @@ func New() MyApp {
 	}
 	hobbiesList := filterable_list.New[filterable_list_item.Component]()
 	hobbiesList.SetItems(hobbies)
+	hobbiesList.SetHighlightChangedTopic(highlightChangedTopic)
 	hobbiesList.SetFocus(true)
 
+	foodsListTitle := text_block.New("My favorite foods:")
+	foodsListTitle.SetClasses("title")
+
+	foods := []filterable_list_item.Component{
+		filterable_list_item.New(text_block.New("Tacos"), "tacos"),
+		filterable_list_item.New(text_block.New("Ramen"), "ramen"),
+		filterable_list_item.New(text_block.New("Dumplings"), "dumplings"),
+	}
+	foodsList := filterable_list.New[filterable_list_item.Component]()
+	foodsList.SetItems(foods)
+	foodsList.SetHighlightChangedTopic(highlightChangedTopic)
+
+	result := &implementation{
+		hobbiesAndTitle:    nil,
+		overlayStack:       nil,
+		keyBindingRegistry: nil,
+		help:               nil,
+		commandRegistry:    nil,
+		commandPalette:     nil,
+		bus:                bubble_bath.NewBus(),
+		theme:              bubble_bath.DarkTheme,
+		width:              0,
+		height:             0,
+	}
+
+	// Shows the bindings of whichever list is focused, plus the app's own (see ShortHelp) and the program's
+	result.keyBindingRegistry = bubble_bath.NewKeyBindingRegistry(result)
+	result.help = help.New(result.keyBindingRegistry)
+
+	result.commandRegistry = bubble_bath.NewCommandRegistry(result)
+	result.commandRegistry.AddGlobalCommands(
+		bubble_bath.Command{
+			Name:        "Toggle theme",
+			Description: "Switch between the dark & light themes",
+			KeyBinding:  toggleThemeBinding,
+			Cmd: func() tea.Msg {
+				return toggleThemeMsg{}
+			},
+		},
+		bubble_bath.Command{
+			Name:        "Toggle help",
+			Description: "Show or hide all the key bindings",
+			KeyBinding:  help.DefaultKeyMap.ToggleFullHelp,
+			Cmd: func() tea.Msg {
+				return toggleFullHelpMsg{}
+			},
+		},
+		bubble_bath.Command{
+			Name:        "Quit",
+			Description: "Exit the app",
+			KeyBinding:  key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")),
+			Cmd:         tea.Quit,
+		},
+	)
+	result.commandPalette = command_palette.New(result.commandRegistry)
+
 	// Will flexibly resize as needed
-	hobbiesAndTitle := flexbox.New(
+	result.hobbiesAndTitle = flexbox.New(
 		[]flexbox.FlexItem{
 			{
 				Component: hobbiesListTitle,
@@ -41,32 +123,114 @@ func New() MyApp {
 				FlexWeight: 1,
 			},
 			{
-				Component:  hobbiesList,
+				Component: foodsListTitle,
+				FixedSize: 1,
+			},
+			{
+				Component:  foodsList,
 				FlexWeight: 1,
 			},
+			{
+				Component: newHighlightStatus(result.bus),
+				FixedSize: 1,
+			},
+			{
+				// Grows when the full help is shown
+				Component: result.help,
+				Basis:     flexbox.MaxContentBasis,
+			},
 		},
 		flexbox.WithDirection(flexbox.Vertical),
 	)
+	result.overlayStack = bubble_bath.NewOverlayStack(result.hobbiesAndTitle)
+
+	return result
+}
+
+func (i *implementation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// While the palette is open, all keys are for its search box
+		if i.commandPalette.IsFocused() {
+			break
+		}
+		if cmd, handled := i.commandPalette.HandleKey(msg); handled {
+			return cmd
+		}
+		switch {
+		case key.Matches(msg, help.DefaultKeyMap.ToggleFullHelp):
+			return i.Update(toggleFullHelpMsg{})
+		case key.Matches(msg, toggleThemeBinding):
+			return i.Update(toggleThemeMsg{})
+		}
+	case toggleFullHelpMsg:
+		i.help.ToggleFullHelp()
+		i.overlayStack.Resize(i.width, i.height)
+		return nil
+	case toggleThemeMsg:
+		if i.theme == bubble_bath.DarkTheme {
+			return bubble_bath.ChangeTheme(bubble_bath.LightTheme)
+		}
+		return bubble_bath.ChangeTheme(bubble_bath.DarkTheme)
+	case bubble_bath.ThemeChangedMsg:
+		i.SetTheme(msg.Current)
+	}
+	return i.overlayStack.Update(msg)
+}
+
+// Mount gives the palette the key map config, since the palette isn't in the tree until it's open but its Open binding
+// is handled here all along
+func (i *implementation) Mount(ctx bubble_bath.MountContext) tea.Cmd {
+	if ctx.KeyMapConfig != nil {
+		i.commandPalette.ApplyKeyMapConfig(ctx.KeyMapConfig)
+	}
+	return nil
+}
 
-	return &implementation{
-		hobbiesAndTitle: hobbiesAndTitle,
-		width:           0,
-		height:          0,
+// ShortHelp gets the app's hotkeys, with the palette's Open binding taken from the palette so that it reflects any
+// remapping
+func (i *implementation) ShortHelp() []key.Binding {
+	return []key.Binding{
+		help.DefaultKeyMap.ToggleFullHelp,
+		toggleThemeBinding,
+		i.commandPalette.GetKeyMap().Open,
 	}
 }
 
-func (i implementation) Update(msg tea.Msg) tea.Cmd {
-	return i.hobbiesAndTitle.Update(msg)
+func (i *implementation) FullHelp() [][]key.Binding {
+	return [][]key.Binding{i.ShortHelp()}
+}
+
+// SetTheme is how the app learns the theme that the program picked for the terminal, which is applied before the first
+// render
+func (i *implementation) SetTheme(theme bubble_bath.Theme) {
+	i.theme = theme
+}
+
+func (i *implementation) GetKeyBindingRegistry() *bubble_bath.KeyBindingRegistry {
+	return i.keyBindingRegistry
+}
+
+func (i *implementation) GetBus() *bubble_bath.Bus {
+	return i.bus
+}
+
+func (i implementation) GetChildren() []bubble_bath.Component {
+	return []bubble_bath.Component{i.overlayStack}
 }
 
 func (i implementation) View() string {
-	return i.hobbiesAndTitle.View()
+	return i.overlayStack.View()
+}
+
+func (i implementation) Draw(canvas *bubble_bath.Canvas) {
+	i.overlayStack.Draw(canvas)
 }
 
 func (i *implementation) Resize(width int, height int) {
 	i.width = width
 	i.height = height
-	i.hobbiesAndTitle.Resize(width, height)
+	i.overlayStack.Resize(width, height)
 }
 
 func (i *implementation) GetWidth() int {
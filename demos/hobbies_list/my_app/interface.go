@@ -4,4 +4,14 @@ import bubble_bath "github.com/mieubrisse/bubble-bath"
 
 type MyApp interface {
 	bubble_bath.InteractiveComponent
+	bubble_bath.Mounter
+	bubble_bath.ThemedComponent
+	bubble_bath.KeyBindingProvider
+
+	// GetKeyBindingRegistry gets the registry that the app's help is generated from, for the program to add its own
+	// bindings to
+	GetKeyBindingRegistry() *bubble_bath.KeyBindingRegistry
+
+	// GetBus gets the bus that the app's components talk to each other over, for the program to deliver events with
+	GetBus() *bubble_bath.Bus
 }
@@ -2,25 +2,38 @@ package my_app
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/flexbox"
 	"github.com/mieubrisse/bubble-bath/resizable_text_block"
-	"github.com/muesli/reflow/wordwrap"
-	"math"
 )
 
 type implementation struct {
-	text resizable_text_block.Component
+	texts flexbox.Component
 
 	width  int
 	height int
 }
 
 func New() MyApp {
-	text := resizable_text_block.New("Four score and seven years ago our fathers brought forth on this continent, a new nation, conceived in Liberty, and dedicated to the proposition that all men are created equal.")
+	textContents := []string{
+		"Mother Mary lordy jesus",
+		"Four score and seven years ago our fathers brought forth on this continent, a new nation, conceived in Liberty, and dedicated to the proposition that all men are created equal.",
+		"Foo bar bang this is a thing",
+	}
+
+	// Each text starts out as wide as it would like to be (flex-basis: max-content), and when there isn't enough space
+	// the wider texts give up more space than the narrower ones, down to their longest word
+	items := make([]flexbox.FlexItem, len(textContents))
+	for idx, contents := range textContents {
+		items[idx] = flexbox.FlexItem{
+			Component:  resizable_text_block.New(contents),
+			Basis:      flexbox.MaxContentBasis,
+			FlexShrink: 1,
+		}
+	}
 
 	return &implementation{
-		text:   text,
+		texts:  flexbox.New(items),
 		width:  0,
 		height: 0,
 	}
@@ -31,63 +44,17 @@ func (i implementation) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (i implementation) View() string {
-	// return i.text.View()
-	texts := []resizable_text_block.Component{
-		resizable_text_block.New("Mother Mary lordy jesus"),
-		resizable_text_block.New("Four score and seven years ago our fathers brought forth on this continent, a new nation, conceived in Liberty, and dedicated to the proposition that all men are created equal."),
-		resizable_text_block.New("Foo bar bang this is a thing"),
-	}
-
-	sizes := make([]int, len(texts))
-	sumMaximumIntrinsicWidths := 0
-	for idx, text := range texts {
-		maxIntrinsicWidth := text.GetMaximumIntrinsicWidth()
-		sizes[idx] = maxIntrinsicWidth
-		sumMaximumIntrinsicWidths += maxIntrinsicWidth
-	}
-
-	// TODO apply flex-grow
-	// TODO make flex-shrink be optional
-	// Apply flex-shrink
-	availableSpace := i.width - sumMaximumIntrinsicWidths
-	if availableSpace < 0 {
-		// This is basically saying that flex-basis == maxContent
-		totalShrinkApplied := 0
-		for idx, size := range sizes {
-			if idx == len(sizes)-1 {
-				// Use all leftover shrink, no matter how the rounding has gone
-				sizes[idx] = size + (availableSpace - totalShrinkApplied)
-				break
-			}
-
-			// We use flex-basis as a multiplier for the shrink factor
-			weight := float64(size) / float64(sumMaximumIntrinsicWidths)
-			shrinkFloat := weight * float64(availableSpace)
-			shrinkInt := int(math.Round(shrinkFloat))
-			totalShrinkApplied += shrinkInt
-			proposedReducedSize := size + shrinkInt
-
-			minimumSize := texts[idx].GetMinimumIntrinsicWidth()
-			sizes[idx] = bubble_bath.GetMaxInt(proposedReducedSize, minimumSize)
-		}
-	}
-
-	results := make([]string, len(texts))
-	for idx, size := range sizes {
-		results[idx] = wordwrap.String(texts[idx].View(), size)
-	}
-
-	result := lipgloss.JoinHorizontal(lipgloss.Top, results...)
+	return i.texts.View()
+}
 
-	// We're technically adding margin/padding
-	return lipgloss.NewStyle().Height(i.height).MaxHeight(i.height).MaxWidth(i.width).Render(result)
+func (i implementation) Draw(canvas *bubble_bath.Canvas) {
+	i.texts.Draw(canvas)
 }
 
 func (i *implementation) Resize(width int, height int) {
 	i.width = width
 	i.height = height
-
-	// i.hobbiesAndTitle.Resize(width, height)
+	i.texts.Resize(width, height)
 }
 
 func (i *implementation) GetWidth() int {
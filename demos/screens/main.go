@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/demos/screens/my_app"
+	"os"
+)
+
+func main() {
+	if _, err := bubble_bath.RunBubbleBathProgram(
+		my_app.New(),
+		[]bubble_bath.BubbleBathOption{
+			bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap),
+
+			// The focused list gets the first chance at each key, and the router captures the back key before it does
+			bubble_bath.WithEventBubbling(),
+		},
+		[]tea.ProgramOption{
+			tea.WithAltScreen(),
+		},
+	); err != nil {
+		fmt.Printf("An error occurred running the program:\n%v", err)
+		os.Exit(1)
+	}
+}
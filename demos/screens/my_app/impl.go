@@ -0,0 +1,173 @@
+package my_app
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/box"
+	"github.com/mieubrisse/bubble-bath/filterable_list"
+	"github.com/mieubrisse/bubble-bath/filterable_list_item"
+	"github.com/mieubrisse/bubble-bath/router"
+	"github.com/mieubrisse/bubble-bath/text_block"
+)
+
+const transitionDuration = 200 * time.Millisecond
+
+var (
+	openBinding = key.NewBinding(key.WithKeys("enter", "l"), key.WithHelp("enter", "open"))
+	backBinding = key.NewBinding(key.WithKeys("esc", "h"), key.WithHelp("esc", "back"))
+)
+
+// page is a node of the app's content, which gets shown as a list of its children (or its description, if it has none)
+type page struct {
+	name        string
+	description string
+	children    []page
+}
+
+var homePage = page{
+	name: "Home",
+	children: []page{
+		{
+			name: "Hobbies",
+			children: []page{
+				{name: "Pourover coffee", description: "Slow, careful, and worth it"},
+				{name: "Coding", description: "Mostly terminal UIs lately"},
+				{name: "Jiu jitsu", description: "Getting squished, but in a fun way"},
+			},
+		},
+		{
+			name: "Favorite foods",
+			children: []page{
+				{name: "Tacos", description: "Al pastor, ideally"},
+				{name: "Ramen", description: "Tonkotsu, extra noodles"},
+				{name: "Dumplings", description: "Any kind, any time"},
+			},
+		},
+	},
+}
+
+type implementation struct {
+	router router.Component
+
+	// The page that each list screen is showing, so we know which child page to open
+	listPages map[bubble_bath.Component]page
+
+	width  int
+	height int
+}
+
+func New() MyApp {
+	result := &implementation{
+		router:    nil,
+		listPages: map[bubble_bath.Component]page{},
+		width:     0,
+		height:    0,
+	}
+	result.router = router.New(
+		result.createScreen(homePage),
+		router.WithBreadcrumbs(),
+		router.WithBackBinding(backBinding),
+		router.WithTransition(transitionDuration),
+	)
+	return result
+}
+
+func (i *implementation) Update(msg tea.Msg) tea.Cmd {
+	return i.router.Update(msg)
+}
+
+// HandleEvent opens the highlighted page, once the focused list has passed on the key
+func (i *implementation) HandleEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !key.Matches(keyMsg, openBinding) {
+		return nil, false
+	}
+	return i.openHighlightedPage(), true
+}
+
+func (i implementation) View() string {
+	return i.router.View()
+}
+
+func (i implementation) Draw(canvas *bubble_bath.Canvas) {
+	i.router.Draw(canvas)
+}
+
+func (i *implementation) Resize(width int, height int) {
+	i.width = width
+	i.height = height
+	i.router.Resize(width, height)
+}
+
+func (i *implementation) GetWidth() int {
+	return i.width
+}
+
+func (i *implementation) GetHeight() int {
+	return i.height
+}
+
+func (i implementation) GetChildren() []bubble_bath.Component {
+	return []bubble_bath.Component{i.router}
+}
+
+func (i implementation) SetFocus(isFocused bool) tea.Cmd {
+	// App is always focused
+	return nil
+}
+
+func (i implementation) IsFocused() bool {
+	return true
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (i *implementation) openHighlightedPage() tea.Cmd {
+	activeComponent := i.router.GetActiveScreen().Component
+	activePage, found := i.listPages[activeComponent]
+	if !found {
+		return nil
+	}
+
+	list := activeComponent.(filterable_list.Component[filterable_list_item.Component])
+	filteredIndices := list.GetFilteredItemIndices()
+	if len(filteredIndices) == 0 {
+		return nil
+	}
+	highlightedPage := activePage.children[filteredIndices[list.GetHighlightedItemIndex()]]
+	return router.Push(i.createScreen(highlightedPage))
+}
+
+// createScreen creates a list of the page's children, or a box with its details if it has no children
+func (i *implementation) createScreen(toShow page) router.Screen {
+	if len(toShow.children) == 0 {
+		return router.Screen{
+			Name: toShow.name,
+			Component: box.New(
+				newPageDetails(toShow.description),
+				box.WithBorder(lipgloss.RoundedBorder()),
+				box.WithPadding(1, 2),
+				box.WithTitle(toShow.name),
+			),
+		}
+	}
+
+	items := make([]filterable_list_item.Component, len(toShow.children))
+	for idx, child := range toShow.children {
+		items[idx] = filterable_list_item.New(text_block.New(child.name), child.name)
+	}
+	list := filterable_list.New[filterable_list_item.Component]()
+	list.SetItems(items)
+	i.listPages[list] = toShow
+
+	return router.Screen{
+		Name:      toShow.name,
+		Component: list,
+	}
+}
@@ -0,0 +1,7 @@
+package my_app
+
+import bubble_bath "github.com/mieubrisse/bubble-bath"
+
+type MyApp interface {
+	bubble_bath.InteractiveComponent
+}
@@ -0,0 +1,164 @@
+package my_app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/progress"
+	"github.com/mieubrisse/bubble-bath/spinner"
+)
+
+const (
+	visitTimerFPS = 1
+
+	// Loading the details is simulated, so that there's something to watch
+	numLoadingSteps      = 10
+	loadingStepsInterval = 150 * time.Millisecond
+)
+
+// pageDetails loads & shows a page's description, along with how long it's been open for
+// The loading & the timer start when the details get mounted (i.e. the page is opened) and stop when they're unmounted
+// (i.e. the page is closed)
+type pageDetails struct {
+	description string
+
+	taskRunner *bubble_bath.TaskRunner
+
+	// Nil until the details are mounted
+	loadingTask *bubble_bath.Task
+
+	// Both follow the loading task
+	loadingSpinner spinner.Component
+	loadingBar     progress.Component
+
+	visitTimer  *bubble_bath.Animation
+	secondsOpen int
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+func newPageDetails(description string) *pageDetails {
+	return &pageDetails{
+		description:    description,
+		taskRunner:     bubble_bath.NewTaskRunner(bubble_bath.DefaultTaskKeyMap),
+		loadingTask:    nil,
+		loadingSpinner: spinner.New(spinner.WithLabel("Loading details")),
+		loadingBar:     progress.New(progress.WithStyle(progress.Smooth), progress.WithPercentage(), progress.WithETA()),
+		visitTimer:     bubble_bath.NewAnimation(visitTimerFPS),
+		secondsOpen:    0,
+		isFocused:      false,
+		width:          0,
+		height:         0,
+	}
+}
+
+func (details *pageDetails) Mount(ctx bubble_bath.MountContext) tea.Cmd {
+	details.secondsOpen = 0
+
+	var loadCmd tea.Cmd
+	details.loadingTask, loadCmd = details.taskRunner.Start("load details", loadDetails)
+	return tea.Batch(
+		details.visitTimer.Start(),
+		loadCmd,
+		details.loadingSpinner.BindTask(details.loadingTask),
+		details.loadingBar.BindTask(details.loadingTask),
+	)
+}
+
+// Unmount stops the timer, and stops loading if the page was closed before the details finished loading
+func (details *pageDetails) Unmount() tea.Cmd {
+	details.visitTimer.Stop()
+	details.taskRunner.CancelAll()
+	return nil
+}
+
+// Update passes messages on to the loading indicators, which ignore anything that isn't about the loading task
+func (details *pageDetails) Update(msg tea.Msg) tea.Cmd {
+	return tea.Batch(details.loadingSpinner.Update(msg), details.loadingBar.Update(msg))
+}
+
+// HandleAnimationFrame updates the timer, and animates the loading indicators
+func (details *pageDetails) HandleAnimationFrame(msg bubble_bath.AnimationFrameMsg) tea.Cmd {
+	if msg.IsFrameFor(details.visitTimer) {
+		details.secondsOpen = int(details.visitTimer.GetElapsed(msg.Time).Seconds())
+	}
+	return tea.Batch(details.loadingSpinner.HandleAnimationFrame(msg), details.loadingBar.HandleAnimationFrame(msg))
+}
+
+func (details *pageDetails) View() string {
+	return lipgloss.NewStyle().
+		Width(details.width).
+		Height(details.height).
+		MaxWidth(details.width).
+		MaxHeight(details.height).
+		Render(details.getContents() + "\n\n" + fmt.Sprintf("Open for %ds", details.secondsOpen))
+}
+
+func (details *pageDetails) Resize(width int, height int) {
+	details.width = width
+	details.height = height
+	details.loadingSpinner.Resize(width, 1)
+	details.loadingBar.Resize(width, 1)
+}
+
+func (details *pageDetails) GetWidth() int {
+	return details.width
+}
+
+func (details *pageDetails) GetHeight() int {
+	return details.height
+}
+
+func (details *pageDetails) SetFocus(isFocused bool) tea.Cmd {
+	details.isFocused = isFocused
+	return nil
+}
+
+func (details *pageDetails) IsFocused() bool {
+	return details.isFocused
+}
+
+// IsFocusable keeps the focus manager from stopping on the details, since there's nothing to do with them
+func (details *pageDetails) IsFocusable() bool {
+	return false
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (details *pageDetails) getContents() string {
+	task := details.loadingTask
+	switch {
+	case task == nil:
+		return ""
+	case !task.IsDone():
+		return details.loadingSpinner.View() + "\n" + details.loadingBar.View()
+	case task.GetError() != nil:
+		// The spinner shows how it failed
+		return details.loadingSpinner.View()
+	}
+	return details.description
+}
+
+func loadDetails(ctx context.Context, reportProgress func(progress bubble_bath.TaskProgress)) (any, error) {
+	for step := 0; step < numLoadingSteps; step++ {
+		reportProgress(bubble_bath.TaskProgress{
+			Completed: int64(step),
+			Total:     numLoadingSteps,
+			Message:   fmt.Sprintf("part %d of %d", step+1, numLoadingSteps),
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(loadingStepsInterval):
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,11 @@
+package bubble_bath
+
+// Direction is a direction on the screen
+type Direction int
+
+const (
+	DirectionUp Direction = iota
+	DirectionDown
+	DirectionLeft
+	DirectionRight
+)
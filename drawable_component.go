@@ -0,0 +1,28 @@
+package bubble_bath
+
+// DrawableComponent is implemented by components that can draw themselves straight into a Canvas, rather than only
+// rendering a string
+// The canvas is exactly the component's size, and clips anything drawn outside it
+type DrawableComponent interface {
+	Component
+
+	Draw(canvas *Canvas)
+}
+
+// DrawComponent draws the component into the canvas, using Draw if the component is a DrawableComponent and its View
+// otherwise
+func DrawComponent(canvas *Canvas, component Component) {
+	if drawable, ok := component.(DrawableComponent); ok {
+		drawable.Draw(canvas)
+		return
+	}
+	canvas.DrawView(0, 0, component.View())
+}
+
+// RenderDrawable renders the component into a fresh canvas of its size and serializes it, which DrawableComponents can
+// use to implement View
+func RenderDrawable(component DrawableComponent) string {
+	canvas := NewCanvas(component.GetWidth(), component.GetHeight())
+	component.Draw(canvas)
+	return canvas.String()
+}
@@ -0,0 +1,66 @@
+package bubble_bath
+
+import (
+	"math"
+	"time"
+)
+
+// EasingFunc maps how far through an animation's duration it is (from 0 to 1) to how far along its motion it should be
+// (usually also from 0 to 1), so that transitions can speed up & slow down rather than moving at a constant rate
+type EasingFunc func(progress float64) float64
+
+func EaseLinear(progress float64) float64 {
+	return progress
+}
+
+func EaseInQuad(progress float64) float64 {
+	return progress * progress
+}
+
+func EaseOutQuad(progress float64) float64 {
+	return 1 - (1-progress)*(1-progress)
+}
+
+func EaseInOutQuad(progress float64) float64 {
+	if progress < 0.5 {
+		return 2 * progress * progress
+	}
+	return 1 - math.Pow(-2*progress+2, 2)/2
+}
+
+func EaseInCubic(progress float64) float64 {
+	return progress * progress * progress
+}
+
+// EaseOutCubic starts fast and settles gently into place, which suits things sliding in
+func EaseOutCubic(progress float64) float64 {
+	return 1 - math.Pow(1-progress, 3)
+}
+
+func EaseInOutCubic(progress float64) float64 {
+	if progress < 0.5 {
+		return 4 * progress * progress * progress
+	}
+	return 1 - math.Pow(-2*progress+2, 3)/2
+}
+
+func EaseInOutSine(progress float64) float64 {
+	return -(math.Cos(math.Pi*progress) - 1) / 2
+}
+
+// GetEasedProgress gets how far along its motion an animation that plays over the given duration should be after the
+// elapsed time, which is 1 once the duration is up
+func GetEasedProgress(elapsed time.Duration, duration time.Duration, easing EasingFunc) float64 {
+	if duration <= 0 || elapsed >= duration {
+		return 1
+	}
+	if elapsed <= 0 {
+		return easing(0)
+	}
+	return easing(float64(elapsed) / float64(duration))
+}
+
+// Interpolate gets the value the given fraction of the way from start to end (e.g. an eased progress)
+func Interpolate(start float64, end float64, fraction float64) float64 {
+	return start + (end-start)*fraction
+}
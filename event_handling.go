@@ -0,0 +1,56 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// EventHandler is a component that takes part in the bubbling phase of event dispatch (see DispatchEvent): it gets
+// events after its focused descendants have had the chance to handle them
+type EventHandler interface {
+	Component
+
+	// HandleEvent handles the event, returning true if it was handled (which stops it from bubbling any further up)
+	HandleEvent(msg tea.Msg) (tea.Cmd, bool)
+}
+
+// EventCapturer is a component that takes part in the capture phase of event dispatch (see DispatchEvent): it gets
+// events before any of its focused descendants, e.g. so that a router can intercept a "back" key no matter which of its
+// screen's components is focused
+type EventCapturer interface {
+	Component
+
+	// CaptureEvent handles the event, returning true if it was handled (which stops it from reaching any of the
+	// component's descendants)
+	CaptureEvent(msg tea.Msg) (tea.Cmd, bool)
+}
+
+// DispatchEvent delivers the event along the focused path(s) of the tree rooted at the given component, returning true
+// if some component handled it
+// This happens in two phases, as in the DOM:
+//  1. Capture: EventCapturers get the event from the root downwards
+//  2. Bubble: EventHandlers get the event from the most deeply focused components upwards
+//
+// Dispatch stops as soon as a component reports the event handled
+func DispatchEvent(root Component, msg tea.Msg) (tea.Cmd, bool) {
+	focusedPath := getFocusedPathComponents(root)
+
+	for idx := len(focusedPath) - 1; idx >= 0; idx-- {
+		capturer, ok := focusedPath[idx].(EventCapturer)
+		if !ok {
+			continue
+		}
+		if cmd, handled := capturer.CaptureEvent(msg); handled {
+			return cmd, true
+		}
+	}
+
+	for _, component := range focusedPath {
+		handler, ok := component.(EventHandler)
+		if !ok {
+			continue
+		}
+		if cmd, handled := handler.HandleEvent(msg); handled {
+			return cmd, true
+		}
+	}
+
+	return nil, false
+}
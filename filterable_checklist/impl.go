@@ -1,7 +1,9 @@
 package filterable_checklist
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
 	"github.com/mieubrisse/bubble-bath/filterable_checklist_item"
 	filterable_list2 "github.com/mieubrisse/bubble-bath/filterable_list"
 )
@@ -14,6 +16,8 @@ type implementation[T filterable_checklist_item.Component] struct {
 	// Indices of selected items within the *unfiltered* list
 	selectedItemIndices map[int]bool
 
+	keyMap KeyMap
+
 	isFocused bool
 	width     int
 	height    int
@@ -25,6 +29,7 @@ func New[T filterable_checklist_item.Component]() Component[T] {
 		innerList:           inner,
 		items:               make([]T, 0),
 		selectedItemIndices: make(map[int]bool, 0),
+		keyMap:              DefaultKeyMap,
 		isFocused:           false,
 		width:               0,
 		height:              0,
@@ -36,37 +41,71 @@ func (impl *implementation[T]) View() string {
 }
 
 func (impl *implementation[T]) Update(msg tea.Msg) tea.Cmd {
-	// Do nothing on non-Keymsgs
-	switch msg.(type) {
+	// Do nothing on non-Keymsgs, except to pass theme changes on to the list
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Proceed to rest of function
-	default:
-		return nil
+		if cmd, handled := impl.HandleEvent(msg); handled {
+			return cmd
+		}
+		return impl.innerList.Update(msg)
+	case bubble_bath.ThemeChangedMsg:
+		return bubble_bath.BroadcastThemeChange(impl.GetChildren(), msg)
 	}
+	return nil
+}
 
-	if !impl.isFocused {
-		return nil
+// HandleEvent handles the selection keys, leaving every other key to bubble up
+// When dispatched with bubble_bath.DispatchEvent, the inner list (which is on the focused path beneath the checklist)
+// gets the first chance at each key, so the checklist only sees the ones the list didn't handle
+func (impl *implementation[T]) HandleEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !impl.isFocused {
+		return nil, false
 	}
 
-	// TODO allow for KeyMap overrides here?
-	var returnCmd tea.Cmd
-	castedMsg := msg.(tea.KeyMsg)
-	switch castedMsg.String() {
-	case "x", "enter":
+	switch {
+	case key.Matches(keyMsg, impl.keyMap.ToggleSelection):
 		impl.ToggleHighlightedItemSelection()
-	case "s":
+	case key.Matches(keyMsg, impl.keyMap.SelectAllViewable):
 		impl.SetAllViewableItemsSelection(true)
-	case "d":
+	case key.Matches(keyMsg, impl.keyMap.DeselectAllViewable):
 		impl.SetAllViewableItemsSelection(false)
-	case "S":
+	case key.Matches(keyMsg, impl.keyMap.SelectAll):
 		impl.SetAllItemsSelection(true)
-	case "D":
+	case key.Matches(keyMsg, impl.keyMap.DeselectAll):
 		impl.SetAllItemsSelection(false)
 	default:
-		returnCmd = impl.innerList.Update(msg)
+		return nil, false
 	}
+	return nil, true
+}
+
+func (impl *implementation[T]) GetKeyMap() KeyMap {
+	return impl.keyMap
+}
+
+func (impl *implementation[T]) SetKeyMap(keyMap KeyMap) {
+	impl.keyMap = keyMap
+}
+
+// ApplyKeyMapConfig only covers the checklist's own bindings, since the inner list is in the tree too and gets the
+// config applied itself
+func (impl *implementation[T]) ApplyKeyMapConfig(config *bubble_bath.KeyMapConfig) {
+	config.Apply(KeyMapType, &impl.keyMap)
+}
+
+// ShortHelp only covers the checklist's own bindings, since the inner list is on the focused path too and reports its
+// own
+func (impl *implementation[T]) ShortHelp() []key.Binding {
+	return impl.keyMap.ShortHelp()
+}
 
-	return returnCmd
+func (impl *implementation[T]) FullHelp() [][]key.Binding {
+	return impl.keyMap.FullHelp()
+}
+
+func (impl *implementation[T]) HandleMouse(msg tea.MouseMsg) tea.Cmd {
+	return impl.innerList.HandleMouse(msg)
 }
 
 func (impl implementation[T]) GetItems() []T {
@@ -81,6 +120,10 @@ func (impl *implementation[T]) SetItems(items []T) {
 	impl.innerList.SetItems(items)
 }
 
+func (impl implementation[T]) GetChildren() []bubble_bath.Component {
+	return []bubble_bath.Component{impl.innerList}
+}
+
 func (impl implementation[T]) GetFilterableList() filterable_list2.Component[T] {
 	return impl.innerList
 }
@@ -144,9 +187,23 @@ func (impl implementation[T]) GetWidth() int {
 	return impl.width
 }
 
+func (impl implementation[T]) GetMinimumIntrinsicWidth() int {
+	return impl.innerList.GetMinimumIntrinsicWidth()
+}
+
+func (impl implementation[T]) GetMaximumIntrinsicWidth() int {
+	return impl.innerList.GetMaximumIntrinsicWidth()
+}
+
+func (impl implementation[T]) GetHeightGivenWidth(width int) int {
+	return impl.innerList.GetHeightGivenWidth(width)
+}
+
 func (impl *implementation[T]) SetFocus(isFocused bool) tea.Cmd {
 	impl.isFocused = isFocused
-	return nil
+
+	// The inner list does the scrolling, so it needs to be focused along with the checklist
+	return impl.innerList.SetFocus(isFocused)
 }
 
 func (impl implementation[T]) IsFocused() bool {
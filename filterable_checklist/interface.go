@@ -8,11 +8,21 @@ import (
 
 type Component[T filterable_checklist_item.Component] interface {
 	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.MouseHandlingComponent
+	bubble_bath.ContainerComponent
+	bubble_bath.KeyBindingProvider
+	bubble_bath.KeyRemappableComponent
+	bubble_bath.EventHandler
 
 	// Used for manipulations of the inner list (no need to reimplement all the functions)
 	// The items in the original list will match the items from GetItems
 	GetFilterableList() filterable_list.Component[T]
 
+	// GetKeyMap gets the checklist's own bindings; the inner list's can be changed through GetFilterableList
+	GetKeyMap() KeyMap
+	SetKeyMap(keyMap KeyMap)
+
 	GetItems() []T
 	// TODO AddItems
 	// TODO RemoveItems
@@ -0,0 +1,59 @@
+package filterable_checklist
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/filterable_list"
+)
+
+// KeyMapType is the name that key map config files use for the checklist's own bindings
+const KeyMapType = "filterable_checklist"
+
+// KeyMap is the checklist's own bindings; moving the highlight is done by the inner list's bindings (see
+// filterable_list.KeyMap)
+type KeyMap struct {
+	ToggleSelection key.Binding
+
+	// Only affect the items that match the filter
+	SelectAllViewable   key.Binding
+	DeselectAllViewable key.Binding
+
+	SelectAll   key.Binding
+	DeselectAll key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	ToggleSelection:     key.NewBinding(key.WithKeys("x", "enter"), key.WithHelp("x", "toggle")),
+	SelectAllViewable:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "select shown")),
+	DeselectAllViewable: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "deselect shown")),
+	SelectAll:           key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "select all")),
+	DeselectAll:         key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "deselect all")),
+}
+
+func (keyMap KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keyMap.ToggleSelection}
+}
+
+func (keyMap KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{keyMap.ToggleSelection, keyMap.SelectAllViewable, keyMap.DeselectAllViewable},
+		{keyMap.SelectAll, keyMap.DeselectAll},
+	}
+}
+
+func (keyMap *KeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"toggle_selection":      &keyMap.ToggleSelection,
+		"select_all_viewable":   &keyMap.SelectAllViewable,
+		"deselect_all_viewable": &keyMap.DeselectAllViewable,
+		"select_all":            &keyMap.SelectAll,
+		"deselect_all":          &keyMap.DeselectAll,
+	}
+}
+
+func init() {
+	bubble_bath.RegisterKeyMapType(KeyMapType, &DefaultKeyMap)
+
+	// The inner list gets the first chance at each key, so a key bound in both would never reach the checklist
+	bubble_bath.RegisterOverlappingKeyMapTypes(KeyMapType, filterable_list.KeyMapType)
+}
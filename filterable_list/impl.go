@@ -1,6 +1,7 @@
 package filterable_list
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mieubrisse/bubble-bath"
@@ -18,6 +19,11 @@ type implementation[T filterable_list_item.Component] struct {
 	// The index of the highlighted item within the *filtered list*
 	highlightedItemIdx int
 
+	keyMap KeyMap
+
+	// Nil if highlight changes aren't being published
+	highlightChangedTopic *bubble_bath.Topic[HighlightChangedEvent[T]]
+
 	isFocused bool
 	width     int
 	height    int
@@ -28,6 +34,8 @@ func New[T filterable_list_item.Component]() Component[T] {
 		unfilteredItems:              make([]T, 0),
 		filteredItemsOriginalIndices: make([]int, 0),
 		highlightedItemIdx:           0,
+		keyMap:                       DefaultKeyMap,
+		highlightChangedTopic:        nil,
 		width:                        0,
 		height:                       0,
 	}
@@ -38,29 +46,7 @@ func (impl implementation[T]) View() string {
 		return ""
 	}
 
-	// As aesthetic choices, when there are more item lines than display lines:
-	// 1. We want the entire list to scroll around the cursor if it's in the center of the screen, rather than
-	//    the user needing to scroll to top or bottom to get the list to move. This helps the user see more
-	//    relevant information at once
-	// 2. When the cursor is near the top or bottom of the list, scroll the cursor rather than the entire list
-	//    so that we don't get blank space
-	// The easiest way to accomplish this is to calculate the range of acceptable first-line indexes of the view,
-	//   which will range from [0, num_items - num_display_lines], and when the user is in the middle of the list
-	//   the view will have the cursor line in the center
-	halfHeight := impl.height / 2
-
-	// Ensure that, when near the bottom of the list, the cursor is no longer centered and scrolls to the bottom
-	firstDisplayedLineIdxInclusive := bubble_bath.GetMinInt(
-		impl.highlightedItemIdx-halfHeight,
-		len(impl.filteredItemsOriginalIndices)-impl.height,
-	)
-
-	// Ensure that, when near the top of the list, the cursor is no longer centered and scrolls to the top
-	firstDisplayedLineIdxInclusive = bubble_bath.GetMaxInt(
-		firstDisplayedLineIdxInclusive,
-		0,
-	)
-
+	firstDisplayedLineIdxInclusive := impl.getFirstDisplayedLineIdx()
 	lastDisplayedLineIdxExclusive := bubble_bath.GetMinInt(
 		len(impl.filteredItemsOriginalIndices),
 		firstDisplayedLineIdxInclusive+impl.height,
@@ -93,31 +79,83 @@ func (impl implementation[T]) View() string {
 }
 
 func (impl *implementation[T]) Update(msg tea.Msg) tea.Cmd {
-	// Do nothing on non-Keymsgs
-	switch msg.(type) {
+	// Do nothing on non-Keymsgs, except to pass theme changes on to every item
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Proceed to rest of function
-	default:
-		return nil
+		cmd, _ := impl.HandleEvent(msg)
+		return cmd
+	case bubble_bath.ThemeChangedMsg:
+		return bubble_bath.BroadcastThemeChange(impl.GetChildren(), msg)
 	}
+	return nil
+}
 
-	if !impl.isFocused {
-		return nil
+// HandleEvent scrolls the list for its navigation keys, leaving every other key to bubble up
+func (impl *implementation[T]) HandleEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !impl.isFocused {
+		return nil, false
 	}
 
-	// TODO allow for KeyMap overrides here?
-	castedMsg := msg.(tea.KeyMsg)
-	switch castedMsg.String() {
-	case "j":
+	oldHighlightedItemOriginalIdx := impl.getHighlightedItemOriginalIdx()
+	switch {
+	case key.Matches(keyMsg, impl.keyMap.Down):
 		impl.Scroll(1)
-	case "k":
+	case key.Matches(keyMsg, impl.keyMap.Up):
 		impl.Scroll(-1)
-	case "J":
+	case key.Matches(keyMsg, impl.keyMap.PageDown):
 		impl.Scroll(impl.height)
-	case "K":
+	case key.Matches(keyMsg, impl.keyMap.PageUp):
 		impl.Scroll(-impl.height)
+	default:
+		return nil, false
 	}
-	return nil
+	return impl.publishHighlightChangeIfNecessary(oldHighlightedItemOriginalIdx), true
+}
+
+func (impl *implementation[T]) GetKeyMap() KeyMap {
+	return impl.keyMap
+}
+
+func (impl *implementation[T]) SetKeyMap(keyMap KeyMap) {
+	impl.keyMap = keyMap
+}
+
+func (impl *implementation[T]) ApplyKeyMapConfig(config *bubble_bath.KeyMapConfig) {
+	config.Apply(KeyMapType, &impl.keyMap)
+}
+
+func (impl *implementation[T]) ShortHelp() []key.Binding {
+	return impl.keyMap.ShortHelp()
+}
+
+func (impl *implementation[T]) FullHelp() [][]key.Binding {
+	return impl.keyMap.FullHelp()
+}
+
+// HandleMouse highlights the clicked item, and scrolls the highlight with the mouse wheel
+func (impl *implementation[T]) HandleMouse(msg tea.MouseMsg) tea.Cmd {
+	oldHighlightedItemOriginalIdx := impl.getHighlightedItemOriginalIdx()
+	switch msg.Type {
+	case tea.MouseLeft:
+		if len(impl.filteredItemsOriginalIndices) == 0 || msg.Y < 0 || msg.Y >= impl.height {
+			return nil
+		}
+		clickedItemIdx := impl.getFirstDisplayedLineIdx() + msg.Y
+		if clickedItemIdx >= len(impl.filteredItemsOriginalIndices) {
+			return nil
+		}
+		impl.Scroll(clickedItemIdx - impl.highlightedItemIdx)
+	case tea.MouseWheelUp:
+		impl.Scroll(-1)
+	case tea.MouseWheelDown:
+		impl.Scroll(1)
+	}
+	return impl.publishHighlightChangeIfNecessary(oldHighlightedItemOriginalIdx)
+}
+
+func (impl *implementation[T]) SetHighlightChangedTopic(topic *bubble_bath.Topic[HighlightChangedEvent[T]]) {
+	impl.highlightChangedTopic = topic
 }
 
 func (impl *implementation[T]) UpdateFilter(newFilter func(idx int, item T) bool, shouldPreserveHighlight bool) {
@@ -200,6 +238,35 @@ func (impl *implementation[T]) Scroll(scrollOffset int) {
 	impl.highlightedItemIdx = newHighlightedItemIdx
 }
 
+// GetChildren gets all the items (not just the ones matching the filter), so that style sheets reach every item
+func (impl implementation[T]) GetChildren() []bubble_bath.Component {
+	results := make([]bubble_bath.Component, len(impl.unfilteredItems))
+	for idx, item := range impl.unfilteredItems {
+		results[idx] = item
+	}
+	return results
+}
+
+// GetChildRectangles gets the line that each displayed item is on, with hidden items getting an empty rectangle
+func (impl implementation[T]) GetChildRectangles() []bubble_bath.Rectangle {
+	results := make([]bubble_bath.Rectangle, len(impl.unfilteredItems))
+
+	firstDisplayedLineIdx := impl.getFirstDisplayedLineIdx()
+	for filteredIdx, originalIdx := range impl.filteredItemsOriginalIndices {
+		lineIdx := filteredIdx - firstDisplayedLineIdx
+		if lineIdx < 0 || lineIdx >= impl.height {
+			continue
+		}
+		results[originalIdx] = bubble_bath.Rectangle{
+			X:      0,
+			Y:      lineIdx,
+			Width:  impl.width,
+			Height: 1,
+		}
+	}
+	return results
+}
+
 func (impl implementation[T]) GetItems() []T {
 	return impl.unfilteredItems
 }
@@ -232,6 +299,27 @@ func (impl implementation[T]) GetWidth() int {
 	return impl.width
 }
 
+func (impl implementation[T]) GetMinimumIntrinsicWidth() int {
+	result := 0
+	for _, item := range impl.unfilteredItems {
+		result = bubble_bath.GetMaxInt(result, bubble_bath.GetMinimumIntrinsicWidth(item))
+	}
+	return result
+}
+
+func (impl implementation[T]) GetMaximumIntrinsicWidth() int {
+	result := 0
+	for _, item := range impl.unfilteredItems {
+		result = bubble_bath.GetMaxInt(result, bubble_bath.GetMaximumIntrinsicWidth(item))
+	}
+	return result
+}
+
+func (impl implementation[T]) GetHeightGivenWidth(width int) int {
+	// Each item gets exactly one line
+	return len(impl.filteredItemsOriginalIndices)
+}
+
 func (impl *implementation[T]) SetFocus(isFocused bool) tea.Cmd {
 	impl.isFocused = isFocused
 	return nil
@@ -240,3 +328,52 @@ func (impl *implementation[T]) SetFocus(isFocused bool) tea.Cmd {
 func (impl implementation[T]) IsFocused() bool {
 	return impl.isFocused
 }
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// getHighlightedItemOriginalIdx gets the index (within the unfiltered list) of the highlighted item, or -1 if no items
+// are being displayed
+func (impl implementation[T]) getHighlightedItemOriginalIdx() int {
+	if len(impl.filteredItemsOriginalIndices) == 0 {
+		return -1
+	}
+	return impl.filteredItemsOriginalIndices[impl.highlightedItemIdx]
+}
+
+func (impl *implementation[T]) publishHighlightChangeIfNecessary(oldHighlightedItemOriginalIdx int) tea.Cmd {
+	newHighlightedItemOriginalIdx := impl.getHighlightedItemOriginalIdx()
+	if impl.highlightChangedTopic == nil ||
+		newHighlightedItemOriginalIdx == -1 ||
+		newHighlightedItemOriginalIdx == oldHighlightedItemOriginalIdx {
+		return nil
+	}
+	return bubble_bath.Publish(impl.highlightChangedTopic, HighlightChangedEvent[T]{
+		List: impl,
+		Item: impl.unfilteredItems[newHighlightedItemOriginalIdx],
+	})
+}
+
+// getFirstDisplayedLineIdx gets the index (within the filtered list) of the item displayed on the first line
+func (impl implementation[T]) getFirstDisplayedLineIdx() int {
+	// As aesthetic choices, when there are more item lines than display lines:
+	// 1. We want the entire list to scroll around the cursor if it's in the center of the screen, rather than
+	//    the user needing to scroll to top or bottom to get the list to move. This helps the user see more
+	//    relevant information at once
+	// 2. When the cursor is near the top or bottom of the list, scroll the cursor rather than the entire list
+	//    so that we don't get blank space
+	// The easiest way to accomplish this is to calculate the range of acceptable first-line indexes of the view,
+	//   which will range from [0, num_items - num_display_lines], and when the user is in the middle of the list
+	//   the view will have the cursor line in the center
+	halfHeight := impl.height / 2
+
+	// Ensure that, when near the bottom of the list, the cursor is no longer centered and scrolls to the bottom
+	result := bubble_bath.GetMinInt(
+		impl.highlightedItemIdx-halfHeight,
+		len(impl.filteredItemsOriginalIndices)-impl.height,
+	)
+
+	// Ensure that, when near the top of the list, the cursor is no longer centered and scrolls to the top
+	return bubble_bath.GetMaxInt(result, 0)
+}
@@ -5,8 +5,22 @@ import (
 	"github.com/mieubrisse/bubble-bath/filterable_list_item"
 )
 
+// HighlightChangedEvent is published when the user moves the list's highlight (see SetHighlightChangedTopic)
+type HighlightChangedEvent[T filterable_list_item.Component] struct {
+	List Component[T]
+
+	// The newly-highlighted item
+	Item T
+}
+
 type Component[T filterable_list_item.Component] interface {
 	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.MouseHandlingComponent
+	bubble_bath.LayoutContainerComponent
+	bubble_bath.KeyBindingProvider
+	bubble_bath.KeyRemappableComponent
+	bubble_bath.EventHandler
 
 	// UpdateFilter updates the filter by which items are currently being shown (or not)
 	// If shouldPreserveHighlight is set, the highlighted item in the pre-update list will be the highlighted item
@@ -22,6 +36,14 @@ type Component[T filterable_list_item.Component] interface {
 	// TODO RemoveItems
 	SetItems(items []T)
 
+	GetKeyMap() KeyMap
+	SetKeyMap(keyMap KeyMap)
+
 	GetFilteredItemIndices() []int
 	GetHighlightedItemIndex() int
+
+	// SetHighlightChangedTopic makes the list publish an event to the topic whenever the user moves the highlight (with
+	// the keyboard or the mouse), so that other components can follow the highlighted item
+	// A nil topic stops the publishing
+	SetHighlightChangedTopic(topic *bubble_bath.Topic[HighlightChangedEvent[T]])
 }
@@ -0,0 +1,46 @@
+package filterable_list
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// KeyMapType is the name that key map config files use for the list's bindings
+const KeyMapType = "filterable_list"
+
+type KeyMap struct {
+	Down     key.Binding
+	Up       key.Binding
+	PageDown key.Binding
+	PageUp   key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	Down:     key.NewBinding(key.WithKeys("j"), key.WithHelp("j", "down")),
+	Up:       key.NewBinding(key.WithKeys("k"), key.WithHelp("k", "up")),
+	PageDown: key.NewBinding(key.WithKeys("J"), key.WithHelp("J", "page down")),
+	PageUp:   key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "page up")),
+}
+
+func (keyMap KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keyMap.Down, keyMap.Up}
+}
+
+func (keyMap KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{keyMap.Down, keyMap.Up, keyMap.PageDown, keyMap.PageUp},
+	}
+}
+
+func (keyMap *KeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"down":      &keyMap.Down,
+		"up":        &keyMap.Up,
+		"page_down": &keyMap.PageDown,
+		"page_up":   &keyMap.PageUp,
+	}
+}
+
+func init() {
+	bubble_bath.RegisterKeyMapType(KeyMapType, &DefaultKeyMap)
+}
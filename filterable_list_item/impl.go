@@ -1,17 +1,17 @@
 package filterable_list_item
 
 import (
-	"github.com/charmbracelet/lipgloss"
 	bubble_bath "github.com/mieubrisse/bubble-bath"
 )
 
-var defaultHighlightedItemStyle = lipgloss.NewStyle().Background(lipgloss.Color("#282828")).Bold(true)
+// StyleType is the name that style sheet type selectors use to match list items
+// Highlighted items are in the bubble_bath.StyleStateHighlighted state
+const StyleType = "filterable_list_item"
 
 // implementation is a basic implementation of a list item
 // More complex implementations can be created as needed
 type implementation struct {
-	// lipgloss.Style to apply to items that are highlighted
-	HighlightedItemStyle lipgloss.Style
+	bubble_bath.StyleNode
 
 	innerComponent bubble_bath.Component
 
@@ -24,27 +24,29 @@ type implementation struct {
 
 func New(innerComponent bubble_bath.Component, value string) Component {
 	return &implementation{
-		HighlightedItemStyle: defaultHighlightedItemStyle,
-		innerComponent:       innerComponent,
-		value:                value,
-		isHighlighted:        false,
-		width:                0,
-		height:               0,
+		StyleNode:      bubble_bath.StyleNode{},
+		innerComponent: innerComponent,
+		value:          value,
+		isHighlighted:  false,
+		width:          0,
+		height:         0,
 	}
 }
 
 func (impl *implementation) View() string {
-	result := impl.innerComponent.View()
-	if impl.isHighlighted {
-		result = impl.HighlightedItemStyle.Render(result)
-	}
-	return result
+	return impl.GetStyle().RenderWithin(impl.innerComponent.View(), impl.width, impl.height)
 }
 
 func (impl *implementation) Resize(width int, height int) {
-	impl.innerComponent.Resize(width, height)
 	impl.width = width
 	impl.height = height
+
+	// The inner component gets whatever's left inside the margins, borders, and padding
+	style := impl.GetStyle()
+	impl.innerComponent.Resize(
+		bubble_bath.GetMaxInt(0, width-style.GetHorizontalFrameSize()),
+		bubble_bath.GetMaxInt(0, height-style.GetVerticalFrameSize()),
+	)
 }
 
 func (impl *implementation) GetWidth() int {
@@ -55,14 +57,46 @@ func (impl *implementation) GetHeight() int {
 	return impl.height
 }
 
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	return bubble_bath.GetMinimumIntrinsicWidth(impl.innerComponent) + impl.GetStyle().GetHorizontalFrameSize()
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return bubble_bath.GetMaximumIntrinsicWidth(impl.innerComponent) + impl.GetStyle().GetHorizontalFrameSize()
+}
+
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	style := impl.GetStyle()
+	innerWidth := bubble_bath.GetMaxInt(0, width-style.GetHorizontalFrameSize())
+	return bubble_bath.GetHeightGivenWidth(impl.innerComponent, innerWidth) + style.GetVerticalFrameSize()
+}
+
 func (impl *implementation) GetValue() string {
 	return impl.value
 }
 
 func (impl *implementation) IsHighlighted() bool {
-	return impl.IsHighlighted()
+	return impl.isHighlighted
 }
 
 func (impl *implementation) SetHighlighted(isHighlighted bool) {
 	impl.isHighlighted = isHighlighted
 }
+
+func (impl *implementation) GetStyleType() string {
+	return StyleType
+}
+
+func (impl *implementation) HasStyleState(state bubble_bath.StyleState) bool {
+	return state == bubble_bath.StyleStateHighlighted && impl.isHighlighted
+}
+
+// SetStyle sets the style, resizing the inner component if the style's margins, borders, or padding changed its space
+func (impl *implementation) SetStyle(style bubble_bath.Style) {
+	oldStyle := impl.GetStyle()
+	impl.StyleNode.SetStyle(style)
+	if style.GetHorizontalFrameSize() != oldStyle.GetHorizontalFrameSize() ||
+		style.GetVerticalFrameSize() != oldStyle.GetVerticalFrameSize() {
+		impl.Resize(impl.width, impl.height)
+	}
+}
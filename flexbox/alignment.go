@@ -0,0 +1,114 @@
+package flexbox
+
+// Alignment is how an item is placed along the flexbox's minor axis (CSS's "align-items" and "align-self")
+type Alignment int
+
+const (
+	// AlignAuto is only valid for FlexItem.AlignSelf, and indicates that the flexbox's item alignment should be used
+	AlignAuto Alignment = iota
+
+	// AlignStart places the item at the top (for horizontal flexboxes) or left (for vertical flexboxes)
+	AlignStart
+
+	// AlignCenter centers the item
+	AlignCenter
+
+	// AlignEnd places the item at the bottom (for horizontal flexboxes) or right (for vertical flexboxes)
+	AlignEnd
+
+	// AlignStretch gives the item the full size of the minor axis
+	AlignStretch
+)
+
+// Justification is how the items are distributed along the flexbox's major axis when they don't fill it
+// (CSS's "justify-content")
+type Justification int
+
+const (
+	// JustifyStart packs the items at the start of the major axis
+	JustifyStart Justification = iota
+
+	// JustifyEnd packs the items at the end of the major axis
+	JustifyEnd
+
+	// JustifyCenter packs the items in the center of the major axis
+	JustifyCenter
+
+	// JustifySpaceBetween puts the first & last items at the edges, with the leftover space spread evenly between items
+	JustifySpaceBetween
+
+	// JustifySpaceAround gives every item equal space on either side (so the space between items is double the space
+	// at the edges)
+	JustifySpaceAround
+
+	// JustifySpaceEvenly makes the space between items and the space at the edges all equal
+	JustifySpaceEvenly
+)
+
+// calculateSpacing gets the space before the first item and the extra space between each pair of items required to
+// distribute the given free space across the given number of items
+// If there's no free space, the items are packed at the start
+func calculateSpacing(justification Justification, freeSpace int, numItems int) (leadingSpace float64, spaceBetween float64) {
+	if freeSpace <= 0 || numItems == 0 {
+		return 0, 0
+	}
+
+	free := float64(freeSpace)
+	n := float64(numItems)
+	switch justification {
+	case JustifyEnd:
+		return free, 0
+	case JustifyCenter:
+		return free / 2, 0
+	case JustifySpaceBetween:
+		if numItems == 1 {
+			return 0, 0
+		}
+		return 0, free / (n - 1)
+	case JustifySpaceAround:
+		return free / (2 * n), free / n
+	case JustifySpaceEvenly:
+		return free / (n + 1), free / (n + 1)
+	default:
+		return 0, 0
+	}
+}
+
+// ContentAlignment is how the lines of a wrapping flexbox are distributed along the minor axis when they don't fill it
+// (CSS's "align-content")
+// It has no effect on flexboxes that don't wrap, as their single line always fills the minor axis
+type ContentAlignment int
+
+const (
+	// ContentStretch splits the leftover space evenly between the lines, making each line bigger
+	ContentStretch ContentAlignment = iota
+
+	// ContentStart packs the lines at the start of the minor axis
+	ContentStart
+
+	// ContentEnd packs the lines at the end of the minor axis
+	ContentEnd
+
+	// ContentCenter packs the lines in the center of the minor axis
+	ContentCenter
+
+	// ContentSpaceBetween puts the first & last lines at the edges, with the leftover space spread evenly between lines
+	ContentSpaceBetween
+
+	// ContentSpaceAround gives every line equal space on either side
+	ContentSpaceAround
+
+	// ContentSpaceEvenly makes the space between lines and the space at the edges all equal
+	ContentSpaceEvenly
+)
+
+// Distributing lines along the minor axis works the same as distributing items along the major axis
+var contentAlignmentJustifications = map[ContentAlignment]Justification{
+	ContentStretch:      JustifyStart,
+	ContentStart:        JustifyStart,
+	ContentEnd:          JustifyEnd,
+	ContentCenter:       JustifyCenter,
+	ContentSpaceBetween: JustifySpaceBetween,
+	ContentSpaceAround:  JustifySpaceAround,
+	ContentSpaceEvenly:  JustifySpaceEvenly,
+}
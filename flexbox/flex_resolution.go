@@ -0,0 +1,149 @@
+package flexbox
+
+import "math"
+
+// flexItemSizing is everything the flex resolution algorithm needs to know about an item, along the major axis
+type flexItemSizing struct {
+	basis   float64
+	minSize float64
+
+	// Can be +Inf to indicate no maximum
+	maxSize float64
+
+	flexGrow   float64
+	flexShrink float64
+}
+
+// resolveFlexibleLengths implements the CSS algorithm for resolving flexible lengths, which grows or shrinks the items
+// from their bases to fill the available space while respecting their min & max sizes
+// See: https://www.w3.org/TR/css-flexbox-1/#resolve-flexible-lengths
+func resolveFlexibleLengths(items []flexItemSizing, availableSpace float64) []float64 {
+	hypotheticalSizes := make([]float64, len(items))
+	sumHypotheticalSizes := 0.0
+	for idx, item := range items {
+		hypotheticalSizes[idx] = clampFloat(item.basis, item.minSize, item.maxSize)
+		sumHypotheticalSizes += hypotheticalSizes[idx]
+	}
+	isGrowing := sumHypotheticalSizes < availableSpace
+
+	getFlexFactor := func(item flexItemSizing) float64 {
+		if isGrowing {
+			return item.flexGrow
+		}
+		return item.flexShrink
+	}
+
+	// Items that can't flex in the direction we need get frozen at their hypothetical size right away
+	targetSizes := make([]float64, len(items))
+	isFrozen := make([]bool, len(items))
+	for idx, item := range items {
+		targetSizes[idx] = hypotheticalSizes[idx]
+		if getFlexFactor(item) == 0 ||
+			(isGrowing && item.basis > hypotheticalSizes[idx]) ||
+			(!isGrowing && item.basis < hypotheticalSizes[idx]) {
+			isFrozen[idx] = true
+		}
+	}
+
+	initialFreeSpace := calculateRemainingFreeSpace(items, targetSizes, isFrozen, availableSpace)
+
+	for {
+		numUnfrozen := 0
+		sumUnfrozenFlexFactors := 0.0
+		for idx, item := range items {
+			if isFrozen[idx] {
+				continue
+			}
+			numUnfrozen++
+			sumUnfrozenFlexFactors += getFlexFactor(item)
+		}
+		if numUnfrozen == 0 {
+			break
+		}
+
+		// Per the spec, if the flex factors add up to less than 1 then the items only get that fraction of the free space
+		remainingFreeSpace := calculateRemainingFreeSpace(items, targetSizes, isFrozen, availableSpace)
+		if sumUnfrozenFlexFactors < 1 {
+			scaledInitialFreeSpace := initialFreeSpace * sumUnfrozenFlexFactors
+			if math.Abs(scaledInitialFreeSpace) < math.Abs(remainingFreeSpace) {
+				remainingFreeSpace = scaledInitialFreeSpace
+			}
+		}
+
+		// Distribute the free space proportionally to the flex factors
+		if isGrowing {
+			for idx, item := range items {
+				if isFrozen[idx] {
+					continue
+				}
+				targetSizes[idx] = item.basis + remainingFreeSpace*(item.flexGrow/sumUnfrozenFlexFactors)
+			}
+		} else {
+			// Shrinking is weighted by basis so that larger items give up more space than smaller ones
+			sumScaledShrinkFactors := 0.0
+			for idx, item := range items {
+				if isFrozen[idx] {
+					continue
+				}
+				sumScaledShrinkFactors += item.flexShrink * item.basis
+			}
+			for idx, item := range items {
+				if isFrozen[idx] {
+					continue
+				}
+				targetSizes[idx] = item.basis
+				if sumScaledShrinkFactors > 0 {
+					shrinkRatio := (item.flexShrink * item.basis) / sumScaledShrinkFactors
+					targetSizes[idx] = item.basis - math.Abs(remainingFreeSpace)*shrinkRatio
+				}
+			}
+		}
+
+		// Fix min/max violations, and freeze the items that violated in the direction of the total violation
+		totalViolation := 0.0
+		violations := make([]float64, len(items))
+		for idx, item := range items {
+			if isFrozen[idx] {
+				continue
+			}
+			clampedSize := clampFloat(targetSizes[idx], item.minSize, item.maxSize)
+			violations[idx] = clampedSize - targetSizes[idx]
+			totalViolation += violations[idx]
+			targetSizes[idx] = clampedSize
+		}
+
+		for idx := range items {
+			if isFrozen[idx] {
+				continue
+			}
+			switch {
+			case totalViolation == 0:
+				isFrozen[idx] = true
+			case totalViolation > 0 && violations[idx] > 0:
+				isFrozen[idx] = true
+			case totalViolation < 0 && violations[idx] < 0:
+				isFrozen[idx] = true
+			}
+		}
+	}
+
+	return targetSizes
+}
+
+// calculateRemainingFreeSpace gets the space left over once frozen items are at their target size and unfrozen items
+// are at their basis
+func calculateRemainingFreeSpace(items []flexItemSizing, targetSizes []float64, isFrozen []bool, availableSpace float64) float64 {
+	result := availableSpace
+	for idx, item := range items {
+		if isFrozen[idx] {
+			result -= targetSizes[idx]
+		} else {
+			result -= item.basis
+		}
+	}
+	return result
+}
+
+func clampFloat(value, low, high float64) float64 {
+	return math.Max(low, math.Min(high, value))
+}
@@ -0,0 +1,98 @@
+package flexbox
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestResolveFlexibleLengths(t *testing.T) {
+	noMax := math.Inf(1)
+	testCases := []struct {
+		name           string
+		items          []flexItemSizing
+		availableSpace float64
+		expected       []float64
+	}{
+		{
+			name: "grows in proportion to flex-grow",
+			items: []flexItemSizing{
+				{basis: 0, minSize: 0, maxSize: noMax, flexGrow: 1, flexShrink: 1},
+				{basis: 0, minSize: 0, maxSize: noMax, flexGrow: 3, flexShrink: 1},
+			},
+			availableSpace: 100,
+			expected:       []float64{25, 75},
+		},
+		{
+			name: "grows from the bases",
+			items: []flexItemSizing{
+				{basis: 10, minSize: 0, maxSize: noMax, flexGrow: 1, flexShrink: 1},
+				{basis: 30, minSize: 0, maxSize: noMax, flexGrow: 1, flexShrink: 1},
+			},
+			availableSpace: 60,
+			expected:       []float64{20, 40},
+		},
+		{
+			name: "items that can't grow keep their basis",
+			items: []flexItemSizing{
+				{basis: 10, minSize: 0, maxSize: noMax, flexGrow: 0, flexShrink: 1},
+				{basis: 0, minSize: 0, maxSize: noMax, flexGrow: 1, flexShrink: 1},
+			},
+			availableSpace: 50,
+			expected:       []float64{10, 40},
+		},
+		{
+			name: "max size violations get frozen & the rest redistributed",
+			items: []flexItemSizing{
+				{basis: 0, minSize: 0, maxSize: 10, flexGrow: 1, flexShrink: 1},
+				{basis: 0, minSize: 0, maxSize: noMax, flexGrow: 1, flexShrink: 1},
+			},
+			availableSpace: 100,
+			expected:       []float64{10, 90},
+		},
+		{
+			name: "shrinking is weighted by basis",
+			items: []flexItemSizing{
+				{basis: 100, minSize: 0, maxSize: noMax, flexGrow: 0, flexShrink: 1},
+				{basis: 50, minSize: 0, maxSize: noMax, flexGrow: 0, flexShrink: 1},
+			},
+			availableSpace: 120,
+			expected:       []float64{80, 40},
+		},
+		{
+			name: "min size violations get frozen & the rest shrinks more",
+			items: []flexItemSizing{
+				{basis: 50, minSize: 45, maxSize: noMax, flexGrow: 0, flexShrink: 1},
+				{basis: 50, minSize: 0, maxSize: noMax, flexGrow: 0, flexShrink: 1},
+			},
+			availableSpace: 60,
+			expected:       []float64{45, 15},
+		},
+		{
+			name: "flex factors adding up to less than 1 only take that fraction of the free space",
+			items: []flexItemSizing{
+				{basis: 0, minSize: 0, maxSize: noMax, flexGrow: 0.5, flexShrink: 1},
+			},
+			availableSpace: 100,
+			expected:       []float64{50},
+		},
+		{
+			name: "fixed items overflow rather than shrinking",
+			items: []flexItemSizing{
+				{basis: 30, minSize: 30, maxSize: 30, flexGrow: 0, flexShrink: 0},
+				{basis: 30, minSize: 30, maxSize: 30, flexGrow: 0, flexShrink: 0},
+			},
+			availableSpace: 40,
+			expected:       []float64{30, 30},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := resolveFlexibleLengths(testCase.items, testCase.availableSpace)
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected sizes %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
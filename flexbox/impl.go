@@ -2,7 +2,6 @@ package flexbox
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	bubble_bath "github.com/mieubrisse/bubble-bath"
 	"math"
 )
@@ -20,6 +19,41 @@ func WithDirection(direction LayoutDirection) FlexboxOption {
 	}
 }
 
+// WithAlignItems sets how items are placed along the minor axis, for items that don't set their own AlignSelf
+func WithAlignItems(alignment Alignment) FlexboxOption {
+	return func(impl *implementation) {
+		impl.alignItems = alignment
+	}
+}
+
+// WithJustifyContent sets how items are distributed along the major axis when they don't fill it
+func WithJustifyContent(justification Justification) FlexboxOption {
+	return func(impl *implementation) {
+		impl.justifyContent = justification
+	}
+}
+
+// WithGap sets the number of blank cells between adjacent items
+func WithGap(gap int) FlexboxOption {
+	return func(impl *implementation) {
+		impl.gap = gap
+	}
+}
+
+// WithWrapping sets whether items that don't fit along the major axis get broken onto additional lines
+func WithWrapping(wrapping Wrapping) FlexboxOption {
+	return func(impl *implementation) {
+		impl.wrapping = wrapping
+	}
+}
+
+// WithAlignContent sets how the lines of a wrapping flexbox are distributed along the minor axis
+func WithAlignContent(alignment ContentAlignment) FlexboxOption {
+	return func(impl *implementation) {
+		impl.alignContent = alignment
+	}
+}
+
 func WithChildFocusManaging(shouldManageChildrenFocus bool) FlexboxOption {
 	return func(impl *implementation) {
 		impl.shouldManageChildrenFocus = shouldManageChildrenFocus
@@ -33,18 +67,73 @@ const (
 	Horizontal
 )
 
+// Wrapping is whether items that don't fit along the major axis get broken onto additional lines (CSS's "flex-wrap")
+type Wrapping int
+
+const (
+	// NoWrap keeps all the items on a single line, shrinking them as necessary
+	NoWrap Wrapping = iota
+
+	// Wrap breaks the items onto as many lines as necessary, with each line's items flexing independently
+	Wrap
+)
+
+const (
+	// MaxContentBasis is a FlexItem.Basis value indicating that the item's basis should be its maximum intrinsic size
+	// along the flexbox's major axis (i.e. CSS's "flex-basis: max-content")
+	MaxContentBasis = -1
+
+	// MinContentBasis is a FlexItem.Basis value indicating that the item's basis should be its minimum intrinsic size
+	// along the flexbox's major axis (i.e. CSS's "flex-basis: min-content")
+	MinContentBasis = -2
+
+	// NoMinSize is a FlexItem.MinSize value indicating that the item may shrink all the way to nothing, rather than
+	// stopping at its minimum intrinsic size
+	NoMinSize = -1
+)
+
 type FlexItem struct {
 	// Required
 	Component bubble_bath.Component
 
 	// The fixed size that the component should take up
 	// 0 indicates no fixed size
-	// Overrides FlexWeight
+	// Overrides all the other sizing fields (the item will neither grow nor shrink)
 	FixedSize int
 
-	// The weight that the item should have, when FixedSize is not set
-	// 0 indicates that the item should get no weight (will be invisible)
+	// Shorthand for a FlexGrow & FlexShrink of the weight with a zero Basis (CSS's "flex: <weight>"), used when FixedSize
+	// and FlexGrow aren't set
+	// 0 indicates that the item should get no weight, and an item with none of the sizing fields set gets no space at all
 	FlexWeight float64
+
+	// The proportion of the leftover space that the item should grow into, relative to the other items
+	// 0 indicates that the item shouldn't grow beyond its basis
+	FlexGrow float64
+
+	// How much the item should shrink when there isn't enough space, relative to the other items
+	// As with CSS, this is multiplied by the item's basis so that larger items give up more space than smaller ones
+	// 0 indicates that the item shouldn't shrink below its basis
+	FlexShrink float64
+
+	// The size of the item along the major axis before any growing or shrinking is done
+	// Can be MaxContentBasis or MinContentBasis to use the component's intrinsic size
+	Basis int
+
+	// The size that the item will never shrink below
+	// 0 indicates an automatic minimum, and NoMinSize indicates no minimum
+	// In a horizontal flexbox the automatic minimum is the component's minimum intrinsic width (CSS's "min-width: auto"),
+	// but in a vertical one it's no minimum at all, since a component's content height is only what it would like (e.g. a
+	// list can scroll through items that don't fit) and treating it as a hard minimum would let tall components starve
+	// their siblings
+	MinSize int
+
+	// The size that the item will never grow beyond
+	// 0 indicates no maximum
+	MaxSize int
+
+	// How the item should be placed along the minor axis
+	// AlignAuto (the default) indicates that the flexbox's item alignment should be used
+	AlignSelf Alignment
 }
 
 type implementation struct {
@@ -52,12 +141,28 @@ type implementation struct {
 
 	direction LayoutDirection
 
+	alignItems     Alignment
+	justifyContent Justification
+	alignContent   ContentAlignment
+	wrapping       Wrapping
+
+	// Used both between items on a line and between lines
+	gap int
+
+	// Where each child sits within the flexbox, as calculated during the last resize
+	childRectangles []bubble_bath.Rectangle
+
 	// "Set" of children that should receive events when the flexbox is focused
 	focusReceivingChildrenIndexes map[int]bool
 
 	// If true, the flexbox will focus and unfocus children when the flexbox itself is focused or unfocused
 	shouldManageChildrenFocus bool
 
+	// Children only get mounted & unmounted as they're added & removed if the flexbox itself is mounted, with the context
+	// the flexbox was mounted with
+	isMounted    bool
+	mountContext bubble_bath.MountContext
+
 	isFocused bool
 	width     int
 	height    int
@@ -71,8 +176,16 @@ func New(items []FlexItem, options ...FlexboxOption) Component {
 	impl := &implementation{
 		items:                         items,
 		direction:                     Horizontal,
+		alignItems:                    AlignStretch,
+		justifyContent:                JustifyStart,
+		alignContent:                  ContentStretch,
+		wrapping:                      NoWrap,
+		gap:                           0,
+		childRectangles:               make([]bubble_bath.Rectangle, len(items)),
 		focusReceivingChildrenIndexes: map[int]bool{},
 		shouldManageChildrenFocus:     defaultShouldHandleChildrenFocus,
+		isMounted:                     false,
+		mountContext:                  bubble_bath.MountContext{KeyMapConfig: nil},
 		isFocused:                     false,
 		width:                         0,
 		height:                        0,
@@ -101,6 +214,11 @@ func New(items []FlexItem, options ...FlexboxOption) Component {
 }
 
 func (impl implementation) Update(msg tea.Msg) tea.Cmd {
+	// Every child needs to restyle itself, focused or not
+	if themeChangedMsg, ok := msg.(bubble_bath.ThemeChangedMsg); ok {
+		return bubble_bath.BroadcastThemeChange(impl.GetChildren(), themeChangedMsg)
+	}
+
 	if !impl.isFocused {
 		return nil
 	}
@@ -120,69 +238,94 @@ func (impl implementation) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (impl implementation) View() string {
-	// For coercing down the size of any unruly children who try to grow too big
-	var bully func(string, int) string
-	if impl.direction == Horizontal {
-		bully = func(view string, itemWidth int) string {
-			return lipgloss.NewStyle().
-				MaxHeight(impl.height).
-				MaxWidth(itemWidth).
-				Render(view)
-		}
-	} else {
-		bully = func(view string, itemHeight int) string {
-			return lipgloss.NewStyle().
-				MaxHeight(itemHeight).
-				MaxWidth(impl.width).
-				Render(view)
-		}
-	}
+	return bubble_bath.RenderDrawable(&impl)
+}
 
-	itemViews := make([]string, len(impl.items))
-	childSizes := impl.calculateChildSizes()
-	for idx, size := range childSizes {
-		itemComponent := impl.items[idx].Component
-		itemViews[idx] = bully(itemComponent.View(), size)
+// Draw draws each child into its own region of the canvas, which keeps any unruly children who try to grow too big
+// within their bounds
+func (impl implementation) Draw(canvas *bubble_bath.Canvas) {
+	for idx, item := range impl.items {
+		bubble_bath.DrawComponent(canvas.SubCanvas(impl.childRectangles[idx]), item.Component)
 	}
+}
 
-	var result string
-	if impl.direction == Horizontal {
-		result = lipgloss.JoinHorizontal(lipgloss.Center, itemViews...)
-	} else {
-		result = lipgloss.JoinVertical(lipgloss.Left, itemViews...)
+func (impl implementation) GetChildren() []bubble_bath.Component {
+	results := make([]bubble_bath.Component, len(impl.items))
+	for idx, item := range impl.items {
+		results[idx] = item.Component
 	}
+	return results
+}
 
-	// Add an extra sanity check to ensure we don't exceed our own bounds
-	return lipgloss.NewStyle().
-		MaxWidth(impl.width).
-		MaxHeight(impl.height).
-		Render(result)
+func (impl implementation) GetChildRectangles() []bubble_bath.Rectangle {
+	return impl.childRectangles
 }
 
-func (impl *implementation) SetFocusReceivingChildren(focusedChildrenIndexSet map[int]bool) {
+func (impl *implementation) SetFocusReceivingChildren(focusedChildrenIndexSet map[int]bool) tea.Cmd {
 	impl.focusReceivingChildrenIndexes = focusedChildrenIndexSet
-	impl.alignChildFocusesIfNecessary()
+	return impl.alignChildFocusesIfNecessary()
 }
 
-func (impl *implementation) Resize(width int, height int) {
-	impl.width = width
-	impl.height = height
+func (impl *implementation) GetItems() []FlexItem {
+	return impl.items
+}
 
-	var resizingFunction func(component bubble_bath.Component, space int)
-	if impl.direction == Horizontal {
-		resizingFunction = func(component bubble_bath.Component, space int) {
-			component.Resize(space, height)
+func (impl *implementation) SetItems(items []FlexItem) tea.Cmd {
+	newComponents := map[bubble_bath.Component]bool{}
+	for _, item := range items {
+		newComponents[item.Component] = true
+	}
+	oldComponents := map[bubble_bath.Component]bool{}
+	focusReceivingComponents := map[bubble_bath.Component]bool{}
+	for idx, item := range impl.items {
+		oldComponents[item.Component] = true
+		if impl.focusReceivingChildrenIndexes[idx] {
+			focusReceivingComponents[item.Component] = true
 		}
-	} else {
-		resizingFunction = func(component bubble_bath.Component, space int) {
-			component.Resize(width, space)
+	}
+
+	cmds := make([]tea.Cmd, 0)
+	for _, item := range impl.items {
+		if newComponents[item.Component] {
+			continue
+		}
+		// Removed children shouldn't be left thinking they're focused
+		if interactiveComponent, ok := item.Component.(bubble_bath.InteractiveComponent); ok && interactiveComponent.IsFocused() {
+			cmds = append(cmds, interactiveComponent.SetFocus(false))
+		}
+		if impl.isMounted {
+			cmds = append(cmds, bubble_bath.UnmountTree(item.Component))
 		}
 	}
 
-	childSizes := impl.calculateChildSizes()
-	for idx, size := range childSizes {
-		childComponent := impl.items[idx].Component
-		resizingFunction(childComponent, size)
+	impl.items = items
+	impl.focusReceivingChildrenIndexes = map[int]bool{}
+	for idx, item := range items {
+		if focusReceivingComponents[item.Component] {
+			impl.focusReceivingChildrenIndexes[idx] = true
+		}
+	}
+	impl.Resize(impl.width, impl.height)
+
+	if impl.isMounted {
+		for _, item := range items {
+			if !oldComponents[item.Component] {
+				cmds = append(cmds, bubble_bath.MountTree(item.Component, impl.mountContext))
+			}
+		}
+	}
+
+	cmds = append(cmds, impl.alignChildFocusesIfNecessary())
+	return tea.Batch(cmds...)
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+
+	impl.childRectangles = impl.calculateChildRectangles()
+	for idx, rectangle := range impl.childRectangles {
+		impl.items[idx].Component.Resize(rectangle.Width, rectangle.Height)
 	}
 }
 
@@ -194,6 +337,77 @@ func (impl implementation) GetHeight() int {
 	return impl.height
 }
 
+func (impl implementation) GetMinimumIntrinsicWidth() int {
+	if impl.direction == Horizontal && impl.wrapping == NoWrap {
+		// The flexbox can shrink until every item is at its minimum size
+		result := impl.getTotalGapSize(len(impl.items))
+		for _, item := range impl.items {
+			result += int(impl.getFlexItemSizing(item, 0).minSize)
+		}
+		return result
+	}
+
+	if impl.direction == Horizontal {
+		// Wrapping flexboxes can shrink until every item is on its own line
+		result := 0
+		for _, item := range impl.items {
+			result = bubble_bath.GetMaxInt(result, int(impl.getFlexItemSizing(item, 0).minSize))
+		}
+		return result
+	}
+
+	result := 0
+	for _, item := range impl.items {
+		result = bubble_bath.GetMaxInt(result, bubble_bath.GetMinimumIntrinsicWidth(item.Component))
+	}
+	return result
+}
+
+func (impl implementation) GetMaximumIntrinsicWidth() int {
+	if impl.direction == Horizontal {
+		// The flexbox would like every item to be its preferred size, within the item's limits
+		result := impl.getTotalGapSize(len(impl.items))
+		for _, item := range impl.items {
+			sizing := impl.getFlexItemSizing(item, 0)
+			maxContentSize := float64(bubble_bath.GetMaximumIntrinsicWidth(item.Component))
+			result += int(math.Max(sizing.minSize, math.Min(sizing.maxSize, maxContentSize)))
+		}
+		return result
+	}
+
+	result := 0
+	for _, item := range impl.items {
+		result = bubble_bath.GetMaxInt(result, bubble_bath.GetMaximumIntrinsicWidth(item.Component))
+	}
+	return result
+}
+
+func (impl implementation) GetHeightGivenWidth(width int) int {
+	if impl.direction == Horizontal {
+		// The height is whatever the lines' tallest items need, with no stretching
+		lines := impl.calculateLines(width, 0)
+		result := impl.getTotalGapSize(len(lines))
+		for _, line := range lines {
+			lineHeight := 0
+			for positionInLine, itemIdx := range line.itemIndices {
+				itemHeight := impl.getIntrinsicCrossAxisSize(impl.items[itemIdx].Component, line.mainAxisSizes[positionInLine])
+				lineHeight = bubble_bath.GetMaxInt(lineHeight, itemHeight)
+			}
+			result += lineHeight
+		}
+		return result
+	}
+
+	// Vertical flexboxes would like every item to be its preferred height, within the item's limits
+	result := impl.getTotalGapSize(len(impl.items))
+	for _, item := range impl.items {
+		sizing := impl.getFlexItemSizing(item, width)
+		contentHeight := float64(bubble_bath.GetHeightGivenWidth(item.Component, width))
+		result += int(math.Max(sizing.minSize, math.Min(sizing.maxSize, contentHeight)))
+	}
+	return result
+}
+
 func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
 	impl.isFocused = isFocused
 	return impl.alignChildFocusesIfNecessary()
@@ -203,44 +417,107 @@ func (impl *implementation) IsFocused() bool {
 	return impl.isFocused
 }
 
+func (impl *implementation) Mount(ctx bubble_bath.MountContext) tea.Cmd {
+	impl.isMounted = true
+	impl.mountContext = ctx
+	return nil
+}
+
+func (impl *implementation) Unmount() tea.Cmd {
+	impl.isMounted = false
+	return nil
+}
+
 // ====================================================================================================
 //                                   Private Helper Functions
 // ====================================================================================================
 
-// Calculates per-child sizes along the major axis of the flexbox
-func (impl *implementation) calculateChildSizes() []int {
-	availableSpaceGetter := bubble_bath.Component.GetWidth
-	if impl.direction == Vertical {
-		availableSpaceGetter = bubble_bath.Component.GetHeight
-	}
+// flexLine is a single line of items in the flexbox (of which there is only ever one, if the flexbox doesn't wrap)
+type flexLine struct {
+	itemIndices []int
 
-	availableSpace := availableSpaceGetter(impl)
+	// The size of each item along the major axis
+	mainAxisSizes []int
 
-	// First, add up the total sizes and fixed sizes
-	totalFixedSizeConsumed := 0
-	totalWeight := 0.0
-	for _, item := range impl.items {
-		if item.FixedSize != 0 {
-			totalFixedSizeConsumed += item.FixedSize
-		} else {
-			totalWeight += item.FlexWeight
+	// The size of the line along the minor axis, before any stretching from align-content
+	crossAxisSize int
+}
+
+// Breaks the items into lines (if wrapping is enabled) and calculates the size of each item along the major axis, given
+// the space available along the major and minor axes
+func (impl *implementation) calculateLines(mainAxisSpace int, crossAxisSpace int) []flexLine {
+	lineItemIndices := make([][]int, 0)
+	if impl.wrapping == NoWrap {
+		allItemIndices := make([]int, len(impl.items))
+		for idx := range impl.items {
+			allItemIndices[idx] = idx
+		}
+		lineItemIndices = append(lineItemIndices, allItemIndices)
+	} else {
+		// Items get put on the current line until their hypothetical sizes (i.e. their bases, within their limits)
+		// would overflow it
+		currentLine := make([]int, 0)
+		currentLineSize := 0
+		for idx, item := range impl.items {
+			sizing := impl.getFlexItemSizing(item, crossAxisSpace)
+			hypotheticalSize := int(math.Ceil(clampFloat(sizing.basis, sizing.minSize, sizing.maxSize)))
+
+			sizeWithItem := hypotheticalSize
+			if len(currentLine) > 0 {
+				sizeWithItem = currentLineSize + impl.gap + hypotheticalSize
+			}
+			if len(currentLine) > 0 && sizeWithItem > mainAxisSpace {
+				lineItemIndices = append(lineItemIndices, currentLine)
+				currentLine = make([]int, 0)
+				sizeWithItem = hypotheticalSize
+			}
+			currentLine = append(currentLine, idx)
+			currentLineSize = sizeWithItem
+		}
+		if len(currentLine) > 0 {
+			lineItemIndices = append(lineItemIndices, currentLine)
 		}
 	}
 
-	spaceForFlexingElements := bubble_bath.GetMaxInt(0, availableSpace-totalFixedSizeConsumed)
-	spacePerWeight := float64(spaceForFlexingElements) / totalWeight
+	results := make([]flexLine, len(lineItemIndices))
+	for lineIdx, itemIndices := range lineItemIndices {
+		mainAxisSizes := impl.calculateLineMainAxisSizes(itemIndices, mainAxisSpace, crossAxisSpace)
+
+		// A single line always fills the minor axis, while multiple lines are only as big as their biggest item
+		lineCrossAxisSize := crossAxisSpace
+		if impl.wrapping != NoWrap {
+			lineCrossAxisSize = 0
+			for positionInLine, itemIdx := range itemIndices {
+				itemCrossAxisSize := impl.getIntrinsicCrossAxisSize(impl.items[itemIdx].Component, mainAxisSizes[positionInLine])
+				lineCrossAxisSize = bubble_bath.GetMaxInt(lineCrossAxisSize, itemCrossAxisSize)
+			}
+		}
 
-	// Now, allocate
-	results := make([]int, len(impl.items))
-	for idx, item := range impl.items {
-		var desiredItemSpace int
-		if item.FixedSize != 0 {
-			desiredItemSpace = item.FixedSize
-		} else {
-			desiredItemSpace = int(math.Round(item.FlexWeight * spacePerWeight))
+		results[lineIdx] = flexLine{
+			itemIndices:   itemIndices,
+			mainAxisSizes: mainAxisSizes,
+			crossAxisSize: lineCrossAxisSize,
 		}
-		actualItemSpace := bubble_bath.GetMinInt(availableSpace, desiredItemSpace)
-		results[idx] = actualItemSpace
+	}
+	return results
+}
+
+// Calculates per-item sizes along the major axis for the items on a single line, given the space available along the
+// major and minor axes
+func (impl *implementation) calculateLineMainAxisSizes(itemIndices []int, availableSpace int, crossAxisSpace int) []int {
+	sizings := make([]flexItemSizing, len(itemIndices))
+	for positionInLine, itemIdx := range itemIndices {
+		sizings[positionInLine] = impl.getFlexItemSizing(impl.items[itemIdx], crossAxisSpace)
+	}
+
+	availableSpace = bubble_bath.GetMaxInt(0, availableSpace-impl.getTotalGapSize(len(itemIndices)))
+	targetSizes := resolveFlexibleLengths(sizings, float64(availableSpace))
+
+	results := make([]int, len(itemIndices))
+	for positionInLine, size := range bubble_bath.RoundPreservingSum(targetSizes) {
+		// If the items' minimum sizes overflow the line, the later items get squeezed out
+		actualItemSpace := bubble_bath.Clamp(size, 0, availableSpace)
+		results[positionInLine] = actualItemSpace
 
 		availableSpace -= actualItemSpace
 	}
@@ -248,6 +525,204 @@ func (impl *implementation) calculateChildSizes() []int {
 	return results
 }
 
+// Translates the user-friendly FlexItem into the numbers needed by the flex resolution algorithm
+func (impl *implementation) getFlexItemSizing(item FlexItem, crossAxisSpace int) flexItemSizing {
+	if item.FixedSize != 0 {
+		fixedSize := float64(item.FixedSize)
+		return flexItemSizing{
+			basis:      fixedSize,
+			minSize:    fixedSize,
+			maxSize:    fixedSize,
+			flexGrow:   0,
+			flexShrink: 0,
+		}
+	}
+
+	flexGrow := item.FlexGrow
+	flexShrink := item.FlexShrink
+	basis := item.Basis
+	if flexGrow == 0 && item.FlexWeight > 0 {
+		flexGrow = item.FlexWeight
+		if flexShrink == 0 {
+			flexShrink = item.FlexWeight
+		}
+		basis = 0
+	}
+
+	// Weighted layouts have always given unweighted items nothing, so an item that doesn't size itself in any way
+	// collapses rather than getting its automatic minimum
+	isUnsized := item.FlexWeight == 0 && item.FlexGrow == 0 && item.FlexShrink == 0 && item.Basis == 0 &&
+		item.MinSize == 0 && item.MaxSize == 0
+
+	var basisSize int
+	switch basis {
+	case MaxContentBasis:
+		basisSize = impl.getMaximumIntrinsicMainAxisSize(item.Component, crossAxisSpace)
+	case MinContentBasis:
+		basisSize = impl.getMinimumIntrinsicMainAxisSize(item.Component, crossAxisSpace)
+	default:
+		basisSize = bubble_bath.GetMaxInt(0, basis)
+	}
+
+	var minSize int
+	switch {
+	case isUnsized:
+		minSize = 0
+	case item.MinSize == 0 && impl.direction == Horizontal:
+		minSize = impl.getMinimumIntrinsicMainAxisSize(item.Component, crossAxisSpace)
+	case item.MinSize > 0:
+		minSize = item.MinSize
+	default:
+		minSize = 0
+	}
+
+	maxSize := math.Inf(1)
+	if item.MaxSize > 0 {
+		// As with CSS, the minimum wins when it conflicts with the maximum
+		maxSize = float64(bubble_bath.GetMaxInt(item.MaxSize, minSize))
+	}
+
+	return flexItemSizing{
+		basis:      float64(basisSize),
+		minSize:    float64(minSize),
+		maxSize:    maxSize,
+		flexGrow:   flexGrow,
+		flexShrink: flexShrink,
+	}
+}
+
+// Gets the smallest size the component is willing to be along the flexbox's major axis
+func (impl *implementation) getMinimumIntrinsicMainAxisSize(component bubble_bath.Component, crossAxisSpace int) int {
+	if impl.direction == Horizontal {
+		return bubble_bath.GetMinimumIntrinsicWidth(component)
+	}
+	return bubble_bath.GetHeightGivenWidth(component, crossAxisSpace)
+}
+
+// Gets the size the component would like to be along the flexbox's major axis
+func (impl *implementation) getMaximumIntrinsicMainAxisSize(component bubble_bath.Component, crossAxisSpace int) int {
+	if impl.direction == Horizontal {
+		return bubble_bath.GetMaximumIntrinsicWidth(component)
+	}
+	return bubble_bath.GetHeightGivenWidth(component, crossAxisSpace)
+}
+
+// Calculates where each child should sit within the flexbox, given the flexbox's current size
+func (impl *implementation) calculateChildRectangles() []bubble_bath.Rectangle {
+	mainAxisSpace, crossAxisSpace := impl.width, impl.height
+	if impl.direction == Vertical {
+		mainAxisSpace, crossAxisSpace = impl.height, impl.width
+	}
+
+	lines := impl.calculateLines(mainAxisSpace, crossAxisSpace)
+
+	// Distribute the lines along the minor axis
+	lineCrossAxisSizes := make([]float64, len(lines))
+	totalUsedCrossAxisSpace := impl.getTotalGapSize(len(lines))
+	for lineIdx, line := range lines {
+		lineCrossAxisSizes[lineIdx] = float64(line.crossAxisSize)
+		totalUsedCrossAxisSpace += line.crossAxisSize
+	}
+	freeCrossAxisSpace := crossAxisSpace - totalUsedCrossAxisSpace
+	if impl.alignContent == ContentStretch && freeCrossAxisSpace > 0 {
+		for lineIdx := range lines {
+			lineCrossAxisSizes[lineIdx] += float64(freeCrossAxisSpace) / float64(len(lines))
+		}
+	}
+	roundedLineCrossAxisSizes := bubble_bath.RoundPreservingSum(lineCrossAxisSizes)
+	lineLeadingSpace, lineSpaceBetween := calculateSpacing(
+		contentAlignmentJustifications[impl.alignContent],
+		crossAxisSpace-totalUsedCrossAxisSpace,
+		len(lines),
+	)
+
+	results := make([]bubble_bath.Rectangle, len(impl.items))
+	linePosition := lineLeadingSpace
+	for lineIdx, line := range lines {
+		lineCrossAxisOffset := int(math.Round(linePosition))
+		lineCrossAxisSize := roundedLineCrossAxisSizes[lineIdx]
+		linePosition += float64(lineCrossAxisSize+impl.gap) + lineSpaceBetween
+
+		// Distribute the items along the major axis
+		totalUsedMainAxisSpace := impl.getTotalGapSize(len(line.itemIndices))
+		for _, size := range line.mainAxisSizes {
+			totalUsedMainAxisSpace += size
+		}
+		leadingSpace, spaceBetween := calculateSpacing(
+			impl.justifyContent,
+			mainAxisSpace-totalUsedMainAxisSpace,
+			len(line.itemIndices),
+		)
+
+		mainAxisPosition := leadingSpace
+		for positionInLine, itemIdx := range line.itemIndices {
+			item := impl.items[itemIdx]
+			mainAxisSize := line.mainAxisSizes[positionInLine]
+			mainAxisOffset := int(math.Round(mainAxisPosition))
+			mainAxisPosition += float64(mainAxisSize+impl.gap) + spaceBetween
+
+			// Place the item within the line along the minor axis
+			crossAxisSize := lineCrossAxisSize
+			crossAxisOffset := 0
+			alignment := impl.getAlignment(item)
+			if alignment != AlignStretch {
+				crossAxisSize = impl.getIntrinsicCrossAxisSize(item.Component, mainAxisSize)
+
+				// Components that have no opinion about their size get the full line, same as if they'd been stretched
+				if crossAxisSize == 0 {
+					crossAxisSize = lineCrossAxisSize
+				}
+				crossAxisSize = bubble_bath.Clamp(crossAxisSize, 0, lineCrossAxisSize)
+
+				switch alignment {
+				case AlignCenter:
+					crossAxisOffset = (lineCrossAxisSize - crossAxisSize) / 2
+				case AlignEnd:
+					crossAxisOffset = lineCrossAxisSize - crossAxisSize
+				}
+			}
+			crossAxisOffset += lineCrossAxisOffset
+
+			if impl.direction == Horizontal {
+				results[itemIdx] = bubble_bath.Rectangle{
+					X:      mainAxisOffset,
+					Y:      crossAxisOffset,
+					Width:  mainAxisSize,
+					Height: crossAxisSize,
+				}
+			} else {
+				results[itemIdx] = bubble_bath.Rectangle{
+					X:      crossAxisOffset,
+					Y:      mainAxisOffset,
+					Width:  crossAxisSize,
+					Height: mainAxisSize,
+				}
+			}
+		}
+	}
+	return results
+}
+
+// Gets the size the component would like to be along the flexbox's minor axis, given its size along the major axis
+func (impl *implementation) getIntrinsicCrossAxisSize(component bubble_bath.Component, mainAxisSize int) int {
+	if impl.direction == Horizontal {
+		return bubble_bath.GetHeightGivenWidth(component, mainAxisSize)
+	}
+	return bubble_bath.GetMaximumIntrinsicWidth(component)
+}
+
+func (impl *implementation) getAlignment(item FlexItem) Alignment {
+	if item.AlignSelf == AlignAuto {
+		return impl.alignItems
+	}
+	return item.AlignSelf
+}
+
+// Gets the total size of the gaps between the given number of items (or lines)
+func (impl *implementation) getTotalGapSize(numItems int) int {
+	return impl.gap * bubble_bath.GetMaxInt(0, numItems-1)
+}
+
 // Idempotently aligns children to the right focus state
 func (impl *implementation) alignChildFocusesIfNecessary() tea.Cmd {
 	if !impl.shouldManageChildrenFocus {
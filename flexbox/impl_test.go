@@ -0,0 +1,149 @@
+package flexbox
+
+import (
+	"reflect"
+	"testing"
+
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// contentSizedComponent is a component that would like to be as tall as its content, like a list
+type contentSizedComponent struct {
+	minWidth      int
+	maxWidth      int
+	contentHeight int
+
+	width  int
+	height int
+}
+
+func (component *contentSizedComponent) View() string {
+	return ""
+}
+
+func (component *contentSizedComponent) Resize(width int, height int) {
+	component.width = width
+	component.height = height
+}
+
+func (component *contentSizedComponent) GetWidth() int {
+	return component.width
+}
+
+func (component *contentSizedComponent) GetHeight() int {
+	return component.height
+}
+
+func (component *contentSizedComponent) GetMinimumIntrinsicWidth() int {
+	return component.minWidth
+}
+
+func (component *contentSizedComponent) GetMaximumIntrinsicWidth() int {
+	return component.maxWidth
+}
+
+func (component *contentSizedComponent) GetHeightGivenWidth(width int) int {
+	return component.contentHeight
+}
+
+func TestChildRectangles(t *testing.T) {
+	testCases := []struct {
+		name      string
+		direction LayoutDirection
+		items     []FlexItem
+		width     int
+		height    int
+		expected  []bubble_bath.Rectangle
+	}{
+		{
+			// Content heights are only preferences, so tall content mustn't starve the items after it
+			name:      "vertical flex items share space regardless of content height",
+			direction: Vertical,
+			items: []FlexItem{
+				{Component: &contentSizedComponent{contentHeight: 1}, FixedSize: 1},
+				{Component: &contentSizedComponent{contentHeight: 20}, FlexWeight: 1},
+				{Component: &contentSizedComponent{contentHeight: 1}, FixedSize: 1},
+				{Component: &contentSizedComponent{contentHeight: 3}, FlexWeight: 1},
+			},
+			width:  20,
+			height: 10,
+			expected: []bubble_bath.Rectangle{
+				{X: 0, Y: 0, Width: 20, Height: 1},
+				{X: 0, Y: 1, Width: 20, Height: 4},
+				{X: 0, Y: 5, Width: 20, Height: 1},
+				{X: 0, Y: 6, Width: 20, Height: 4},
+			},
+		},
+		{
+			name:      "vertical explicit min size is respected",
+			direction: Vertical,
+			items: []FlexItem{
+				{Component: &contentSizedComponent{contentHeight: 20}, FlexWeight: 1, MinSize: 7},
+				{Component: &contentSizedComponent{contentHeight: 3}, FlexWeight: 1},
+			},
+			width:  20,
+			height: 10,
+			expected: []bubble_bath.Rectangle{
+				{X: 0, Y: 0, Width: 20, Height: 7},
+				{X: 0, Y: 7, Width: 20, Height: 3},
+			},
+		},
+		{
+			name:      "horizontal items don't shrink below their minimum intrinsic width",
+			direction: Horizontal,
+			items: []FlexItem{
+				{Component: &contentSizedComponent{minWidth: 8, maxWidth: 8}, FlexWeight: 1},
+				{Component: &contentSizedComponent{minWidth: 0, maxWidth: 4}, FlexWeight: 3},
+			},
+			width:  10,
+			height: 1,
+			expected: []bubble_bath.Rectangle{
+				{X: 0, Y: 0, Width: 8, Height: 1},
+				{X: 8, Y: 0, Width: 2, Height: 1},
+			},
+		},
+		{
+			name:      "items without any sizing collapse",
+			direction: Horizontal,
+			items: []FlexItem{
+				{Component: &contentSizedComponent{minWidth: 3, maxWidth: 5}, FlexWeight: 1},
+				{Component: &contentSizedComponent{minWidth: 3, maxWidth: 5}},
+				{Component: &contentSizedComponent{minWidth: 3, maxWidth: 5}, FlexWeight: 1},
+			},
+			width:  10,
+			height: 1,
+			expected: []bubble_bath.Rectangle{
+				{X: 0, Y: 0, Width: 5, Height: 1},
+				{X: 5, Y: 0, Width: 0, Height: 1},
+				{X: 5, Y: 0, Width: 5, Height: 1},
+			},
+		},
+		{
+			name:      "flex weights share the space left by fixed items",
+			direction: Horizontal,
+			items: []FlexItem{
+				{Component: &contentSizedComponent{minWidth: 0, maxWidth: 20}, FlexWeight: 1},
+				{Component: &contentSizedComponent{minWidth: 1, maxWidth: 1}, FixedSize: 4},
+				{Component: &contentSizedComponent{minWidth: 0, maxWidth: 20}, FlexWeight: 2},
+			},
+			width:  10,
+			height: 1,
+			expected: []bubble_bath.Rectangle{
+				{X: 0, Y: 0, Width: 2, Height: 1},
+				{X: 2, Y: 0, Width: 4, Height: 1},
+				{X: 6, Y: 0, Width: 4, Height: 1},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			box := New(testCase.items, WithDirection(testCase.direction))
+			box.Resize(testCase.width, testCase.height)
+			actual := box.GetChildRectangles()
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected rectangles %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
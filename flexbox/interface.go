@@ -1,13 +1,29 @@
 package flexbox
 
-import bubble_bath "github.com/mieubrisse/bubble-bath"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
 
 // Component is a flexbox component which will automatically handle resizing and focus-event routing for multiple children
 type Component interface {
 	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.FocusRoutingContainerComponent
+	bubble_bath.LayoutContainerComponent
+	bubble_bath.DrawableComponent
+	bubble_bath.Mounter
+	bubble_bath.Unmounter
+
+	GetItems() []FlexItem
+
+	// SetItems replaces the flexbox's items, mounting the children that are new and unmounting the ones that were
+	// removed (if the flexbox is mounted)
+	// Children that were receiving focus before keep receiving it, even if they've moved
+	SetItems(items []FlexItem) tea.Cmd
 
 	// SetFocusReceivingChildren indicates which children should be focused when the flexbox is focused
 	// All focused children receive all events
 	// Children that are not bubble_bath.InteractiveComponent will of course not receive an event
-	SetFocusReceivingChildren(focusReceivingChildrenIndexes map[int]bool)
+	SetFocusReceivingChildren(focusReceivingChildrenIndexes map[int]bool) tea.Cmd
 }
@@ -0,0 +1,362 @@
+package bubble_bath
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FocusKeyMap is the key bindings that the FocusManager uses to move focus around the component tree
+type FocusKeyMap struct {
+	// Tab-order movement
+	Next     key.Binding
+	Previous key.Binding
+
+	// Spatial movement, to the nearest focusable component in the direction
+	Up    key.Binding
+	Down  key.Binding
+	Left  key.Binding
+	Right key.Binding
+}
+
+// DefaultFocusKeyMap moves focus in tab order with Tab and Shift-Tab, and spatially with Ctrl+H/J/K/L
+var DefaultFocusKeyMap = FocusKeyMap{
+	Next:     key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+	Previous: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous")),
+	Up:       key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "focus up")),
+	Down:     key.NewBinding(key.WithKeys("ctrl+j"), key.WithHelp("ctrl+j", "focus down")),
+	Left:     key.NewBinding(key.WithKeys("ctrl+h"), key.WithHelp("ctrl+h", "focus left")),
+	Right:    key.NewBinding(key.WithKeys("ctrl+l"), key.WithHelp("ctrl+l", "focus right")),
+}
+
+// ShortHelp gets the tab-order bindings, which are the ones most worth advertising
+func (keyMap FocusKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keyMap.Next, keyMap.Previous}
+}
+
+func (keyMap FocusKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{keyMap.Next, keyMap.Previous},
+		{keyMap.Up, keyMap.Down, keyMap.Left, keyMap.Right},
+	}
+}
+
+// FocusKeyMapType is the name that key map config files use for the FocusManager's bindings
+const FocusKeyMapType = "focus"
+
+func (keyMap *FocusKeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"next":     &keyMap.Next,
+		"previous": &keyMap.Previous,
+		"up":       &keyMap.Up,
+		"down":     &keyMap.Down,
+		"left":     &keyMap.Left,
+		"right":    &keyMap.Right,
+	}
+}
+
+func init() {
+	RegisterKeyMapType(FocusKeyMapType, &DefaultFocusKeyMap)
+}
+
+// FocusChangedMsg is sent by the FocusManager whenever it moves focus
+type FocusChangedMsg struct {
+	// Will be nil if nothing was focused before
+	Previous InteractiveComponent
+
+	Current InteractiveComponent
+}
+
+// RequestFocusMsg asks the FocusManager to move focus to the given component
+type RequestFocusMsg struct {
+	Target InteractiveComponent
+}
+
+// RequestFocus is a tea.Cmd factory that components can use to ask for focus to be moved to a component (e.g. a list
+// that wants the search box focused when the user presses "/")
+func RequestFocus(target InteractiveComponent) tea.Cmd {
+	return func() tea.Msg {
+		return RequestFocusMsg{Target: target}
+	}
+}
+
+// FocusManager keeps a ring of the focusable components in a component tree and moves focus between them, setting the
+// focus of every component on the path from the root so that events get routed to the focused component
+//
+// The focusable components are the InteractiveComponents that have no focusable descendants (e.g. a list inside a
+// flexbox, but not the flexbox itself) and don't report being unfocusable via FocusabilityReportingComponent,
+// discovered by walking ContainerComponent children in order
+// The tree is re-walked every time focus moves, so children can be added & removed freely
+type FocusManager struct {
+	KeyMap FocusKeyMap
+
+	root Component
+
+	// The currently-focused component, which will be nil if nothing has been focused yet
+	focused InteractiveComponent
+}
+
+func NewFocusManager(root Component, keyMap FocusKeyMap) *FocusManager {
+	return &FocusManager{
+		KeyMap:  keyMap,
+		root:    root,
+		focused: nil,
+	}
+}
+
+// Init focuses the first focusable component, unless one of the focusable components is already focused
+// Either way, everything on the path down to the focused component gets focused too
+func (manager *FocusManager) Init() tea.Cmd {
+	targetPaths := manager.getFocusTargetPaths()
+	for _, path := range targetPaths {
+		target := getPathTarget(path)
+		if target.IsFocused() {
+			return manager.Focus(target)
+		}
+	}
+
+	if len(targetPaths) == 0 {
+		return nil
+	}
+	return manager.Focus(getPathTarget(targetPaths[0]))
+}
+
+// HandleKey moves focus if the key matches one of the manager's bindings, returning true if it did
+func (manager *FocusManager) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, manager.KeyMap.Next):
+		return manager.FocusNext(), true
+	case key.Matches(msg, manager.KeyMap.Previous):
+		return manager.FocusPrevious(), true
+	case key.Matches(msg, manager.KeyMap.Up):
+		return manager.FocusInDirection(DirectionUp), true
+	case key.Matches(msg, manager.KeyMap.Down):
+		return manager.FocusInDirection(DirectionDown), true
+	case key.Matches(msg, manager.KeyMap.Left):
+		return manager.FocusInDirection(DirectionLeft), true
+	case key.Matches(msg, manager.KeyMap.Right):
+		return manager.FocusInDirection(DirectionRight), true
+	}
+	return nil, false
+}
+
+// GetFocused gets the currently-focused component, or nil if nothing is focused
+func (manager *FocusManager) GetFocused() InteractiveComponent {
+	return manager.focused
+}
+
+// FocusNext moves focus to the next focusable component, wrapping around at the end
+func (manager *FocusManager) FocusNext() tea.Cmd {
+	return manager.moveFocusByOffset(1)
+}
+
+// FocusPrevious moves focus to the previous focusable component, wrapping around at the start
+func (manager *FocusManager) FocusPrevious() tea.Cmd {
+	return manager.moveFocusByOffset(-1)
+}
+
+// FocusInDirection moves focus to the focusable component that's geometrically closest to the focused one in the given
+// direction, based on the rectangles reported by the LayoutContainerComponents in the tree
+// Focus doesn't move if there's nothing in that direction
+func (manager *FocusManager) FocusInDirection(direction Direction) tea.Cmd {
+	targetPaths := manager.getFocusTargetPaths()
+	if len(targetPaths) == 0 {
+		return nil
+	}
+
+	manager.syncWithTree(targetPaths)
+
+	absoluteRectangles := GetAbsoluteRectangles(manager.root)
+	originRectangle, found := absoluteRectangles[manager.focused]
+	if manager.focused == nil || !found {
+		return manager.Focus(getPathTarget(targetPaths[0]))
+	}
+
+	candidates := make([]InteractiveComponent, 0, len(targetPaths))
+	candidateRectangles := make([]Rectangle, 0, len(targetPaths))
+	for _, path := range targetPaths {
+		target := getPathTarget(path)
+		if target == manager.focused {
+			continue
+		}
+		candidates = append(candidates, target)
+		candidateRectangles = append(candidateRectangles, absoluteRectangles[target])
+	}
+
+	nearestIdx := findNearestRectangleInDirection(originRectangle, candidateRectangles, direction)
+	if nearestIdx == -1 {
+		return nil
+	}
+	return manager.Focus(candidates[nearestIdx])
+}
+
+// Focus moves focus to the given component, which must be a focusable component in the manager's tree
+// Components on the path to the previously-focused component are unfocused, and components on the path to the newly
+// focused one are focused
+func (manager *FocusManager) Focus(target InteractiveComponent) tea.Cmd {
+	newPath := findPath(manager.root, target)
+	if newPath == nil {
+		return nil
+	}
+
+	var oldPath []Component
+	if manager.focused != nil {
+		oldPath = findPath(manager.root, manager.focused)
+	}
+
+	cmds := make([]tea.Cmd, 0)
+
+	// Unfocus, from the bottom up, everything that was on the old path but isn't on the new one
+	newPathSet := map[Component]bool{}
+	for _, component := range newPath {
+		newPathSet[component] = true
+	}
+	for idx := len(oldPath) - 1; idx >= 0; idx-- {
+		component := oldPath[idx]
+		if newPathSet[component] {
+			continue
+		}
+		if interactiveComponent, ok := component.(InteractiveComponent); ok && interactiveComponent.IsFocused() {
+			cmds = append(cmds, interactiveComponent.SetFocus(false))
+		}
+	}
+
+	// Focus, from the top down, everything on the new path, making sure each container routes focus to the right child
+	for idx, component := range newPath {
+		if idx < len(newPath)-1 {
+			if container, ok := component.(FocusRoutingContainerComponent); ok {
+				childIdx := indexOfChild(container, newPath[idx+1])
+				cmds = append(cmds, container.SetFocusReceivingChildren(map[int]bool{childIdx: true}))
+			}
+		}
+		if interactiveComponent, ok := component.(InteractiveComponent); ok {
+			cmds = append(cmds, interactiveComponent.SetFocus(true))
+		}
+	}
+
+	previous := manager.focused
+	manager.focused = target
+	cmds = append(cmds, func() tea.Msg {
+		return FocusChangedMsg{
+			Previous: previous,
+			Current:  target,
+		}
+	})
+
+	return tea.Batch(cmds...)
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (manager *FocusManager) moveFocusByOffset(offset int) tea.Cmd {
+	targetPaths := manager.getFocusTargetPaths()
+	if len(targetPaths) == 0 {
+		return nil
+	}
+
+	manager.syncWithTree(targetPaths)
+
+	currentIdx := -1
+	for idx, path := range targetPaths {
+		if getPathTarget(path) == manager.focused {
+			currentIdx = idx
+			break
+		}
+	}
+
+	var newIdx int
+	if currentIdx == -1 {
+		// Nothing (that still exists) is focused, so start from whichever end we're moving away from
+		newIdx = 0
+		if offset < 0 {
+			newIdx = len(targetPaths) - 1
+		}
+	} else {
+		newIdx = (currentIdx + offset) % len(targetPaths)
+		if newIdx < 0 {
+			newIdx += len(targetPaths)
+		}
+	}
+
+	return manager.Focus(getPathTarget(targetPaths[newIdx]))
+}
+
+// syncWithTree catches the manager up if focus was moved without it (e.g. an OverlayStack moving focus to an overlay,
+// or back again when the overlay closes), by adopting whichever focusable component is focused now
+func (manager *FocusManager) syncWithTree(targetPaths [][]Component) {
+	isFocusedInTree := false
+	for _, path := range targetPaths {
+		if getPathTarget(path) == manager.focused {
+			isFocusedInTree = true
+			break
+		}
+	}
+	if isFocusedInTree && manager.focused.IsFocused() {
+		return
+	}
+
+	for _, path := range targetPaths {
+		target := getPathTarget(path)
+		if target.IsFocused() {
+			manager.focused = target
+			return
+		}
+	}
+}
+
+// Gets the path from the root to each focusable component, in focus traversal order
+func (manager *FocusManager) getFocusTargetPaths() [][]Component {
+	results := make([][]Component, 0)
+	collectFocusTargetPaths(manager.root, []Component{}, &results)
+	return results
+}
+
+// Collects the paths to the focusable components at or below the given component, returning true if any were found
+func collectFocusTargetPaths(component Component, pathToParent []Component, results *[][]Component) bool {
+	path := make([]Component, len(pathToParent)+1)
+	copy(path, pathToParent)
+	path[len(pathToParent)] = component
+
+	foundInDescendants := false
+	for _, child := range GetChildren(component) {
+		if collectFocusTargetPaths(child, path, results) {
+			foundInDescendants = true
+		}
+	}
+	if foundInDescendants {
+		return true
+	}
+
+	if IsFocusable(component) {
+		*results = append(*results, path)
+		return true
+	}
+	return false
+}
+
+// Finds the path from the root to the target, or nil if the target isn't in the tree
+func findPath(root Component, target Component) []Component {
+	if root == target {
+		return []Component{root}
+	}
+	for _, child := range GetChildren(root) {
+		if pathFromChild := findPath(child, target); pathFromChild != nil {
+			return append([]Component{root}, pathFromChild...)
+		}
+	}
+	return nil
+}
+
+func indexOfChild(container ContainerComponent, child Component) int {
+	for idx, candidate := range container.GetChildren() {
+		if candidate == child {
+			return idx
+		}
+	}
+	return -1
+}
+
+func getPathTarget(path []Component) InteractiveComponent {
+	return path[len(path)-1].(InteractiveComponent)
+}
@@ -0,0 +1,20 @@
+package bubble_bath
+
+// FocusabilityReportingComponent is implemented by InteractiveComponents that can only sometimes take focus (e.g. a
+// wrapper around a component that may or may not be interactive), so that the FocusManager skips them when they can't
+type FocusabilityReportingComponent interface {
+	InteractiveComponent
+
+	IsFocusable() bool
+}
+
+// IsFocusable indicates whether the component is an InteractiveComponent that can currently take focus
+func IsFocusable(component Component) bool {
+	if _, ok := component.(InteractiveComponent); !ok {
+		return false
+	}
+	if reporter, ok := component.(FocusabilityReportingComponent); ok {
+		return reporter.IsFocusable()
+	}
+	return true
+}
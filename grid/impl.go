@@ -0,0 +1,503 @@
+package grid
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"math"
+	"strings"
+)
+
+const (
+	defaultShouldHandleChildrenFocus = true
+
+	// The name used in area templates to indicate a cell that belongs to no area
+	emptyAreaName = "."
+)
+
+type GridOption func(*implementation)
+
+// WithRowGap sets the number of blank lines between adjacent rows
+func WithRowGap(gap int) GridOption {
+	return func(impl *implementation) {
+		impl.rowGap = gap
+	}
+}
+
+// WithColumnGap sets the number of blank columns between adjacent columns
+func WithColumnGap(gap int) GridOption {
+	return func(impl *implementation) {
+		impl.columnGap = gap
+	}
+}
+
+// WithAreas names regions of the grid so that items can be placed by GridItem.Area (CSS's "grid-template-areas")
+// Each string is a row of space-separated area names, one per column, with "." indicating a cell that belongs to no area
+// E.g. []string{"header header", "sidebar main"}
+func WithAreas(rowTemplates []string) GridOption {
+	return func(impl *implementation) {
+		impl.areas = parseAreas(rowTemplates)
+	}
+}
+
+func WithChildFocusManaging(shouldManageChildrenFocus bool) GridOption {
+	return func(impl *implementation) {
+		impl.shouldManageChildrenFocus = shouldManageChildrenFocus
+	}
+}
+
+type GridItem struct {
+	// Required
+	Component bubble_bath.Component
+
+	// The index of the row & column where the item's top-left corner should go
+	// Ignored if Area is set
+	Row    int
+	Column int
+
+	// The number of rows & columns the item should cover
+	// 0 is treated as 1
+	// Ignored if Area is set
+	RowSpan    int
+	ColumnSpan int
+
+	// The name of the area (from WithAreas) that the item should cover
+	// Items with an area that doesn't exist won't be displayed
+	Area string
+}
+
+// cellSpan is the block of cells that an item covers
+type cellSpan struct {
+	row        int
+	column     int
+	rowSpan    int
+	columnSpan int
+}
+
+type implementation struct {
+	rows    []Track
+	columns []Track
+	items   []GridItem
+
+	rowGap    int
+	columnGap int
+
+	// Area name -> the cells it covers
+	areas map[string]cellSpan
+
+	// Where each child sits within the grid, as calculated during the last resize
+	childRectangles []bubble_bath.Rectangle
+
+	// "Set" of children that should receive events when the grid is focused
+	focusReceivingChildrenIndexes map[int]bool
+
+	// If true, the grid will focus and unfocus children when the grid itself is focused or unfocused
+	shouldManageChildrenFocus bool
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+// New constructs a new grid Component with the given row & column tracks
+// As a convenience, if child focus management is enabled and any of the children are focused then:
+// - those children will be set to receive focus from the grid
+// - the grid's focus state will be set to true
+func New(rows []Track, columns []Track, items []GridItem, options ...GridOption) Component {
+	impl := &implementation{
+		rows:                          rows,
+		columns:                       columns,
+		items:                         items,
+		rowGap:                        0,
+		columnGap:                     0,
+		areas:                         map[string]cellSpan{},
+		childRectangles:               make([]bubble_bath.Rectangle, len(items)),
+		focusReceivingChildrenIndexes: map[int]bool{},
+		shouldManageChildrenFocus:     defaultShouldHandleChildrenFocus,
+		isFocused:                     false,
+		width:                         0,
+		height:                        0,
+	}
+	for _, opt := range options {
+		opt(impl)
+	}
+
+	if impl.shouldManageChildrenFocus {
+		newFocusReceivingChildrenIndexes := map[int]bool{}
+		for idx, item := range impl.items {
+			switch component := item.Component.(type) {
+			case bubble_bath.InteractiveComponent:
+				if component.IsFocused() {
+					newFocusReceivingChildrenIndexes[idx] = true
+					impl.isFocused = true
+				}
+			}
+		}
+		impl.focusReceivingChildrenIndexes = newFocusReceivingChildrenIndexes
+	}
+
+	impl.alignChildFocusesIfNecessary()
+
+	return impl
+}
+
+func (impl implementation) Update(msg tea.Msg) tea.Cmd {
+	// Every child needs to restyle itself, focused or not
+	if themeChangedMsg, ok := msg.(bubble_bath.ThemeChangedMsg); ok {
+		return bubble_bath.BroadcastThemeChange(impl.GetChildren(), themeChangedMsg)
+	}
+
+	if !impl.isFocused {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0)
+	for idx, item := range impl.items {
+		if _, found := impl.focusReceivingChildrenIndexes[idx]; !found {
+			continue
+		}
+
+		switch component := item.Component.(type) {
+		case bubble_bath.InteractiveComponent:
+			cmds = append(cmds, component.Update(msg))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (impl implementation) View() string {
+	return bubble_bath.RenderDrawable(&impl)
+}
+
+func (impl implementation) Draw(canvas *bubble_bath.Canvas) {
+	for idx, item := range impl.items {
+		bubble_bath.DrawComponent(canvas.SubCanvas(impl.childRectangles[idx]), item.Component)
+	}
+}
+
+func (impl implementation) GetChildren() []bubble_bath.Component {
+	results := make([]bubble_bath.Component, len(impl.items))
+	for idx, item := range impl.items {
+		results[idx] = item.Component
+	}
+	return results
+}
+
+func (impl implementation) GetChildRectangles() []bubble_bath.Rectangle {
+	return impl.childRectangles
+}
+
+func (impl *implementation) SetFocusReceivingChildren(focusedChildrenIndexSet map[int]bool) tea.Cmd {
+	impl.focusReceivingChildrenIndexes = focusedChildrenIndexSet
+	return impl.alignChildFocusesIfNecessary()
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+
+	columnSizes := resolveTrackSizes(impl.columns, width, impl.columnGap, impl.getColumnContentSizes())
+	rowSizes := resolveTrackSizes(impl.rows, height, impl.rowGap, impl.getRowContentSizes(columnSizes))
+
+	columnOffsets := calculateTrackOffsets(columnSizes, impl.columnGap)
+	rowOffsets := calculateTrackOffsets(rowSizes, impl.rowGap)
+
+	for idx, item := range impl.items {
+		span, found := impl.getCellSpan(item)
+		if !found {
+			impl.childRectangles[idx] = bubble_bath.Rectangle{}
+			item.Component.Resize(0, 0)
+			continue
+		}
+
+		rectangle := bubble_bath.Rectangle{
+			X:      columnOffsets[span.column],
+			Y:      rowOffsets[span.row],
+			Width:  getSpannedSize(columnSizes, impl.columnGap, span.column, span.columnSpan),
+			Height: getSpannedSize(rowSizes, impl.rowGap, span.row, span.rowSpan),
+		}
+		impl.childRectangles[idx] = rectangle
+		item.Component.Resize(rectangle.Width, rectangle.Height)
+	}
+}
+
+func (impl implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl implementation) GetHeight() int {
+	return impl.height
+}
+
+func (impl implementation) GetMinimumIntrinsicWidth() int {
+	contentSizes := impl.getColumnContentSizes()
+	result := getTotalGapSize(len(impl.columns), impl.columnGap)
+	for idx, column := range impl.columns {
+		if column.kind == fixedTrack {
+			result += column.size
+		} else {
+			result += contentSizes[idx].minimum
+		}
+	}
+	return result
+}
+
+func (impl implementation) GetMaximumIntrinsicWidth() int {
+	contentSizes := impl.getColumnContentSizes()
+	result := getTotalGapSize(len(impl.columns), impl.columnGap)
+	for idx, column := range impl.columns {
+		if column.kind == fixedTrack {
+			result += column.size
+		} else {
+			result += contentSizes[idx].maximum
+		}
+	}
+	return result
+}
+
+func (impl implementation) GetHeightGivenWidth(width int) int {
+	columnSizes := resolveTrackSizes(impl.columns, width, impl.columnGap, impl.getColumnContentSizes())
+	contentSizes := impl.getRowContentSizes(columnSizes)
+
+	result := getTotalGapSize(len(impl.rows), impl.rowGap)
+	for idx, row := range impl.rows {
+		if row.kind == fixedTrack {
+			result += row.size
+		} else {
+			result += contentSizes[idx].maximum
+		}
+	}
+	return result
+}
+
+func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
+	impl.isFocused = isFocused
+	return impl.alignChildFocusesIfNecessary()
+}
+
+func (impl *implementation) IsFocused() bool {
+	return impl.isFocused
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// trackContentSize is the range of sizes that the items in a track would like the track to be
+type trackContentSize struct {
+	minimum int
+	maximum int
+}
+
+// Gets the cells that the item covers, clamped to the bounds of the grid, or false if the item can't be placed
+func (impl *implementation) getCellSpan(item GridItem) (cellSpan, bool) {
+	span := cellSpan{
+		row:        item.Row,
+		column:     item.Column,
+		rowSpan:    bubble_bath.GetMaxInt(1, item.RowSpan),
+		columnSpan: bubble_bath.GetMaxInt(1, item.ColumnSpan),
+	}
+	if item.Area != "" {
+		areaSpan, found := impl.areas[item.Area]
+		if !found {
+			return cellSpan{}, false
+		}
+		span = areaSpan
+	}
+
+	if span.row < 0 || span.row >= len(impl.rows) || span.column < 0 || span.column >= len(impl.columns) {
+		return cellSpan{}, false
+	}
+	span.rowSpan = bubble_bath.GetMinInt(span.rowSpan, len(impl.rows)-span.row)
+	span.columnSpan = bubble_bath.GetMinInt(span.columnSpan, len(impl.columns)-span.column)
+	return span, true
+}
+
+// Gets the intrinsic widths of the items in each column, considering only items that don't span multiple columns
+func (impl *implementation) getColumnContentSizes() []trackContentSize {
+	results := make([]trackContentSize, len(impl.columns))
+	for _, item := range impl.items {
+		span, found := impl.getCellSpan(item)
+		if !found || span.columnSpan != 1 {
+			continue
+		}
+		contentSize := &results[span.column]
+		contentSize.minimum = bubble_bath.GetMaxInt(contentSize.minimum, bubble_bath.GetMinimumIntrinsicWidth(item.Component))
+		contentSize.maximum = bubble_bath.GetMaxInt(contentSize.maximum, bubble_bath.GetMaximumIntrinsicWidth(item.Component))
+	}
+	return results
+}
+
+// Gets the intrinsic heights of the items in each row given the already-resolved column sizes, considering only items
+// that don't span multiple rows
+func (impl *implementation) getRowContentSizes(columnSizes []int) []trackContentSize {
+	results := make([]trackContentSize, len(impl.rows))
+	for _, item := range impl.items {
+		span, found := impl.getCellSpan(item)
+		if !found || span.rowSpan != 1 {
+			continue
+		}
+		itemWidth := getSpannedSize(columnSizes, impl.columnGap, span.column, span.columnSpan)
+		itemHeight := bubble_bath.GetHeightGivenWidth(item.Component, itemWidth)
+
+		contentSize := &results[span.row]
+		contentSize.minimum = bubble_bath.GetMaxInt(contentSize.minimum, itemHeight)
+		contentSize.maximum = bubble_bath.GetMaxInt(contentSize.maximum, itemHeight)
+	}
+	return results
+}
+
+// Idempotently aligns children to the right focus state
+func (impl *implementation) alignChildFocusesIfNecessary() tea.Cmd {
+	if !impl.shouldManageChildrenFocus {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0)
+	for idx, item := range impl.items {
+		switch component := item.Component.(type) {
+		case bubble_bath.InteractiveComponent:
+			_, canChildReceiveFocus := impl.focusReceivingChildrenIndexes[idx]
+
+			shouldChildBeFocused := canChildReceiveFocus && impl.isFocused
+
+			// Skip sending the focus event for children that are already in the desired state
+			if component.IsFocused() == shouldChildBeFocused {
+				continue
+			}
+
+			cmds = append(cmds, component.SetFocus(shouldChildBeFocused))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Sizes the tracks: fixed tracks get their size, auto tracks get their content's maximum size (shrinking towards the
+// content's minimum size if there isn't enough space), and fractional tracks split whatever's left over
+func resolveTrackSizes(tracks []Track, availableSpace int, gap int, contentSizes []trackContentSize) []int {
+	availableSpace = bubble_bath.GetMaxInt(0, availableSpace-getTotalGapSize(len(tracks), gap))
+
+	results := make([]int, len(tracks))
+	usedSpace := 0
+	for idx, track := range tracks {
+		if track.kind == fixedTrack {
+			// If the fixed tracks overflow the grid, the later ones get squeezed out
+			results[idx] = bubble_bath.Clamp(track.size, 0, availableSpace-usedSpace)
+			usedSpace += results[idx]
+		}
+	}
+
+	totalAutoMaximum := 0
+	totalAutoMinimum := 0
+	for idx, track := range tracks {
+		if track.kind == autoTrack {
+			totalAutoMaximum += contentSizes[idx].maximum
+			totalAutoMinimum += bubble_bath.GetMinInt(contentSizes[idx].minimum, contentSizes[idx].maximum)
+		}
+	}
+	spaceForAutoTracks := bubble_bath.GetMaxInt(totalAutoMinimum, availableSpace-usedSpace)
+	spaceToRemove := bubble_bath.GetMaxInt(0, totalAutoMaximum-spaceForAutoTracks)
+	shrinkableSpace := totalAutoMaximum - totalAutoMinimum
+
+	// Auto tracks give up space in proportion to how much they're able to shrink
+	autoSizes := make([]float64, len(tracks))
+	for idx, track := range tracks {
+		if track.kind != autoTrack {
+			continue
+		}
+		maximum := float64(contentSizes[idx].maximum)
+		if spaceToRemove > 0 && shrinkableSpace > 0 {
+			minimum := math.Min(float64(contentSizes[idx].minimum), maximum)
+			maximum -= float64(spaceToRemove) * (maximum - minimum) / float64(shrinkableSpace)
+		}
+		autoSizes[idx] = maximum
+	}
+	for idx, size := range bubble_bath.RoundPreservingSum(autoSizes) {
+		if tracks[idx].kind == autoTrack {
+			results[idx] = size
+			usedSpace += size
+		}
+	}
+
+	totalFraction := 0.0
+	for _, track := range tracks {
+		if track.kind == fractionalTrack {
+			totalFraction += track.fraction
+		}
+	}
+	if totalFraction == 0 {
+		return results
+	}
+
+	spaceForFractionalTracks := float64(bubble_bath.GetMaxInt(0, availableSpace-usedSpace))
+	fractionalSizes := make([]float64, len(tracks))
+	for idx, track := range tracks {
+		if track.kind == fractionalTrack {
+			fractionalSizes[idx] = spaceForFractionalTracks * track.fraction / totalFraction
+		}
+	}
+	for idx, size := range bubble_bath.RoundPreservingSum(fractionalSizes) {
+		if tracks[idx].kind == fractionalTrack {
+			results[idx] = size
+		}
+	}
+
+	return results
+}
+
+// Gets the position where each track starts
+func calculateTrackOffsets(trackSizes []int, gap int) []int {
+	results := make([]int, len(trackSizes))
+	position := 0
+	for idx, size := range trackSizes {
+		results[idx] = position
+		position += size + gap
+	}
+	return results
+}
+
+// Gets the size of the given number of tracks, including the gaps between them
+func getSpannedSize(trackSizes []int, gap int, startIdx int, span int) int {
+	result := getTotalGapSize(span, gap)
+	for idx := startIdx; idx < startIdx+span; idx++ {
+		result += trackSizes[idx]
+	}
+	return result
+}
+
+// Gets the total size of the gaps between the given number of tracks
+func getTotalGapSize(numTracks int, gap int) int {
+	return gap * bubble_bath.GetMaxInt(0, numTracks-1)
+}
+
+// Parses area templates into the cells covered by each area
+// Areas that aren't rectangular cover their bounding box
+func parseAreas(rowTemplates []string) map[string]cellSpan {
+	results := map[string]cellSpan{}
+	for rowIdx, rowTemplate := range rowTemplates {
+		for columnIdx, areaName := range strings.Fields(rowTemplate) {
+			if areaName == emptyAreaName {
+				continue
+			}
+
+			span, found := results[areaName]
+			if !found {
+				results[areaName] = cellSpan{
+					row:        rowIdx,
+					column:     columnIdx,
+					rowSpan:    1,
+					columnSpan: 1,
+				}
+				continue
+			}
+
+			lastRow := bubble_bath.GetMaxInt(span.row+span.rowSpan-1, rowIdx)
+			lastColumn := bubble_bath.GetMaxInt(span.column+span.columnSpan-1, columnIdx)
+			span.row = bubble_bath.GetMinInt(span.row, rowIdx)
+			span.column = bubble_bath.GetMinInt(span.column, columnIdx)
+			span.rowSpan = lastRow - span.row + 1
+			span.columnSpan = lastColumn - span.column + 1
+			results[areaName] = span
+		}
+	}
+	return results
+}
@@ -0,0 +1,83 @@
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveTrackSizes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tracks         []Track
+		availableSpace int
+		gap            int
+		contentSizes   []trackContentSize
+		expected       []int
+	}{
+		{
+			name:           "fractions split the space left after fixed tracks",
+			tracks:         []Track{Fixed(10), Fraction(1), Fraction(3)},
+			availableSpace: 50,
+			gap:            0,
+			contentSizes:   make([]trackContentSize, 3),
+			expected:       []int{10, 10, 30},
+		},
+		{
+			name:           "gaps come out of the available space",
+			tracks:         []Track{Fraction(1), Fraction(1)},
+			availableSpace: 21,
+			gap:            1,
+			contentSizes:   make([]trackContentSize, 2),
+			expected:       []int{10, 10},
+		},
+		{
+			name:           "auto tracks get their content's maximum size when there's room",
+			tracks:         []Track{Auto(), Fraction(1)},
+			availableSpace: 30,
+			gap:            0,
+			contentSizes:   []trackContentSize{{minimum: 4, maximum: 12}, {}},
+			expected:       []int{12, 18},
+		},
+		{
+			name:           "auto tracks shrink towards their content's minimum size",
+			tracks:         []Track{Auto(), Auto()},
+			availableSpace: 20,
+			gap:            0,
+			contentSizes:   []trackContentSize{{minimum: 5, maximum: 15}, {minimum: 5, maximum: 15}},
+			expected:       []int{10, 10},
+		},
+		{
+			name:           "oversized fixed tracks get clamped to the available space",
+			tracks:         []Track{Fixed(15), Fixed(15), Fraction(1)},
+			availableSpace: 20,
+			gap:            0,
+			contentSizes:   make([]trackContentSize, 3),
+			expected:       []int{15, 5, 0},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := resolveTrackSizes(testCase.tracks, testCase.availableSpace, testCase.gap, testCase.contentSizes)
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected track sizes %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseAreas(t *testing.T) {
+	actual := parseAreas([]string{
+		"header header",
+		"sidebar main",
+		"sidebar .",
+	})
+	expected := map[string]cellSpan{
+		"header":  {row: 0, column: 0, rowSpan: 1, columnSpan: 2},
+		"sidebar": {row: 1, column: 0, rowSpan: 2, columnSpan: 1},
+		"main":    {row: 1, column: 1, rowSpan: 1, columnSpan: 1},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected areas %v but got %v", expected, actual)
+	}
+}
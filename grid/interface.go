@@ -0,0 +1,21 @@
+package grid
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// Component is a grid layout component which places children into cells formed by row & column tracks, and will
+// automatically handle resizing and focus-event routing for them
+type Component interface {
+	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.FocusRoutingContainerComponent
+	bubble_bath.LayoutContainerComponent
+	bubble_bath.DrawableComponent
+
+	// SetFocusReceivingChildren indicates which children should be focused when the grid is focused
+	// All focused children receive all events
+	// Children that are not bubble_bath.InteractiveComponent will of course not receive an event
+	SetFocusReceivingChildren(focusReceivingChildrenIndexes map[int]bool) tea.Cmd
+}
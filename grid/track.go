@@ -0,0 +1,47 @@
+package grid
+
+type trackKind int
+
+const (
+	fixedTrack trackKind = iota
+	fractionalTrack
+	autoTrack
+)
+
+// Track is the sizing of a single row or column of the grid
+// Tracks are sized once for the whole grid (not per-row or per-column), so cells always line up
+type Track struct {
+	kind     trackKind
+	size     int
+	fraction float64
+}
+
+// Fixed creates a track that is always exactly the given size
+func Fixed(size int) Track {
+	return Track{
+		kind:     fixedTrack,
+		size:     size,
+		fraction: 0,
+	}
+}
+
+// Fraction creates a track that takes up the given fraction of the space left over after the fixed and auto tracks are
+// sized, relative to the other fractional tracks (CSS's "fr" unit)
+func Fraction(fraction float64) Track {
+	return Track{
+		kind:     fractionalTrack,
+		size:     0,
+		fraction: fraction,
+	}
+}
+
+// Auto creates a track that is sized to fit the intrinsic sizes of the items in it, shrinking towards their minimum
+// intrinsic sizes when there isn't enough space
+// Only items that don't span multiple tracks are considered
+func Auto() Track {
+	return Track{
+		kind:     autoTrack,
+		size:     0,
+		fraction: 0,
+	}
+}
@@ -0,0 +1,126 @@
+package help
+
+import (
+	bubbles_help "github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// KeyMap is the bindings that apps commonly wire up to the help component
+// The help component isn't focusable so it doesn't handle these itself; the app should match them & register them (e.g.
+// with bubble_bath.KeyBindingRegistry.AddGlobalBindings) so they show up in the help
+type KeyMap struct {
+	ToggleFullHelp key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	ToggleFullHelp: key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+}
+
+type implementation struct {
+	keyMap bubbles_help.KeyMap
+
+	model bubbles_help.Model
+
+	theme bubble_bath.Theme
+
+	width  int
+	height int
+}
+
+// New creates a help component for the given bindings, which can be anything that implements the bubbles help.KeyMap
+// interface (e.g. a bubble_bath.KeyBindingRegistry or bubble_bath.KeyBindingProvider)
+func New(keyMap bubbles_help.KeyMap) Component {
+	result := &implementation{
+		keyMap: keyMap,
+		model:  bubbles_help.New(),
+		theme:  bubble_bath.Theme{},
+		width:  0,
+		height: 0,
+	}
+
+	// The program applies its theme before the first render, so there's no need to query the terminal here
+	result.SetTheme(bubble_bath.DarkTheme)
+	return result
+}
+
+func (impl *implementation) View() string {
+	return lipgloss.NewStyle().
+		Width(impl.width).
+		Height(impl.height).
+		MaxWidth(impl.width).
+		MaxHeight(impl.height).
+		Render(impl.renderAtWidth(impl.width))
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+}
+
+func (impl *implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl *implementation) GetHeight() int {
+	return impl.height
+}
+
+// GetMinimumIntrinsicWidth is 0, since help that doesn't fit gets cut short
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	return 0
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return lipgloss.Width(impl.renderAtWidth(0))
+}
+
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	return lipgloss.Height(impl.renderAtWidth(width))
+}
+
+func (impl *implementation) IsShowingFullHelp() bool {
+	return impl.model.ShowAll
+}
+
+func (impl *implementation) SetShowFullHelp(isShowingFullHelp bool) {
+	impl.model.ShowAll = isShowingFullHelp
+}
+
+func (impl *implementation) ToggleFullHelp() {
+	impl.model.ShowAll = !impl.model.ShowAll
+}
+
+// SetTheme re-derives the help styles from the theme
+func (impl *implementation) SetTheme(theme bubble_bath.Theme) {
+	if theme == impl.theme {
+		return
+	}
+	impl.theme = theme
+
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Foreground)
+	descStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	separatorStyle := lipgloss.NewStyle().Foreground(theme.Border)
+	impl.model.Styles = bubbles_help.Styles{
+		Ellipsis:       separatorStyle,
+		ShortKey:       keyStyle,
+		ShortDesc:      descStyle,
+		ShortSeparator: separatorStyle,
+		FullKey:        keyStyle,
+		FullDesc:       descStyle,
+		FullSeparator:  separatorStyle,
+	}
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// renderAtWidth renders the help with the given width limit (0 meaning no limit), which drops the bindings or columns
+// that don't fit
+func (impl *implementation) renderAtWidth(width int) string {
+	model := impl.model
+	model.Width = width
+	return model.View(impl.keyMap)
+}
@@ -0,0 +1,14 @@
+package help
+
+import bubble_bath "github.com/mieubrisse/bubble-bath"
+
+// Component renders help for a set of key bindings (e.g. a bubble_bath.KeyBindingRegistry, so that the help follows
+// focus around the app), either as a single line of the most important bindings or as columns of all of them
+type Component interface {
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.ThemedComponent
+
+	IsShowingFullHelp() bool
+	SetShowFullHelp(isShowingFullHelp bool)
+	ToggleFullHelp()
+}
@@ -0,0 +1,52 @@
+package bubble_bath
+
+// IntrinsicallySizedComponent is an optional interface that lets a component suggest sizes back up the tree, the
+// same way the web has intrinsic (content-driven) sizes alongside extrinsic (parent-imposed) ones
+// Layout containers consult it before calling Resize, so that children get a say in how big they should be
+type IntrinsicallySizedComponent interface {
+	Component
+
+	// GetMinimumIntrinsicWidth gets the narrowest width the component can be squeezed down to while still displaying
+	// its content (e.g. the longest word, for text that can wrap)
+	// This is the terminal equivalent of CSS's "min-content"
+	GetMinimumIntrinsicWidth() int
+
+	// GetMaximumIntrinsicWidth gets the width the component would prefer to take up if there were no constraints
+	// (e.g. the length of the longest line, for text that can wrap)
+	// This is the terminal equivalent of CSS's "max-content"
+	GetMaximumIntrinsicWidth() int
+
+	// GetHeightGivenWidth gets the height that the component's content would need if it were given the specified width
+	GetHeightGivenWidth(width int) int
+}
+
+// GetMinimumIntrinsicWidth gets the minimum intrinsic width of the component if it implements
+// IntrinsicallySizedComponent, or 0 (i.e. "no opinion") otherwise
+func GetMinimumIntrinsicWidth(component Component) int {
+	sizedComponent, ok := component.(IntrinsicallySizedComponent)
+	if !ok {
+		return 0
+	}
+	return sizedComponent.GetMinimumIntrinsicWidth()
+}
+
+// GetMaximumIntrinsicWidth gets the maximum intrinsic width of the component if it implements
+// IntrinsicallySizedComponent, or 0 (i.e. "no opinion") otherwise
+func GetMaximumIntrinsicWidth(component Component) int {
+	sizedComponent, ok := component.(IntrinsicallySizedComponent)
+	if !ok {
+		return 0
+	}
+	// A component should never prefer to be narrower than it can be squeezed
+	return GetMaxInt(sizedComponent.GetMinimumIntrinsicWidth(), sizedComponent.GetMaximumIntrinsicWidth())
+}
+
+// GetHeightGivenWidth gets the height the component would need at the given width if it implements
+// IntrinsicallySizedComponent, or 0 (i.e. "no opinion") otherwise
+func GetHeightGivenWidth(component Component, width int) int {
+	sizedComponent, ok := component.(IntrinsicallySizedComponent)
+	if !ok {
+		return 0
+	}
+	return sizedComponent.GetHeightGivenWidth(width)
+}
@@ -0,0 +1,16 @@
+package bubble_bath
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyBindingProvider is a component that reports the key bindings it responds to, so that help views can be generated
+// from them rather than written by hand
+// The methods match the bubbles help.KeyMap interface, so a provider can also be given straight to a bubbles help.Model
+type KeyBindingProvider interface {
+	Component
+
+	// ShortHelp gets the component's most important bindings, for a single line of help
+	ShortHelp() []key.Binding
+
+	// FullHelp gets all the component's bindings, grouped into columns
+	FullHelp() [][]key.Binding
+}
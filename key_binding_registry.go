@@ -0,0 +1,88 @@
+package bubble_bath
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyBindingRegistry collects the key bindings that are active right now: those of the KeyBindingProviders on the
+// focused path through the component tree (innermost first, since the most specific bindings matter most), followed by
+// the app-wide bindings added to it (e.g. quitting or moving focus; see WithKeyBindingRegistry)
+// The focused path is re-walked on every call, so the bindings follow focus as it moves
+// Disabled bindings are left out
+//
+// It implements the bubbles help.KeyMap interface, so it can be given to the help component (or a bubbles help.Model)
+type KeyBindingRegistry struct {
+	root Component
+
+	// App-wide bindings, grouped the way they should be shown in full help
+	globalBindingGroups [][]key.Binding
+}
+
+func NewKeyBindingRegistry(root Component) *KeyBindingRegistry {
+	return &KeyBindingRegistry{
+		root:                root,
+		globalBindingGroups: make([][]key.Binding, 0),
+	}
+}
+
+// AddGlobalBindings adds app-wide bindings that are active regardless of focus, which will be shown together as one
+// column of full help
+func (registry *KeyBindingRegistry) AddGlobalBindings(bindings ...key.Binding) {
+	if len(bindings) == 0 {
+		return
+	}
+	registry.globalBindingGroups = append(registry.globalBindingGroups, bindings)
+}
+
+// GetFocusedProviders gets the KeyBindingProviders on the focused path through the tree, innermost first
+func (registry *KeyBindingRegistry) GetFocusedProviders() []KeyBindingProvider {
+	results := make([]KeyBindingProvider, 0)
+	for _, component := range getFocusedPathComponents(registry.root) {
+		if provider, ok := component.(KeyBindingProvider); ok {
+			results = append(results, provider)
+		}
+	}
+	return results
+}
+
+// ShortHelp gets the short help of each focused provider, followed by the app-wide bindings
+func (registry *KeyBindingRegistry) ShortHelp() []key.Binding {
+	results := make([]key.Binding, 0)
+	for _, provider := range registry.GetFocusedProviders() {
+		results = append(results, getEnabledBindings(provider.ShortHelp())...)
+	}
+	for _, group := range registry.globalBindingGroups {
+		results = append(results, getEnabledBindings(group)...)
+	}
+	return results
+}
+
+// FullHelp gets the full help columns of each focused provider, followed by a column for each group of app-wide bindings
+func (registry *KeyBindingRegistry) FullHelp() [][]key.Binding {
+	results := make([][]key.Binding, 0)
+	for _, provider := range registry.GetFocusedProviders() {
+		for _, group := range provider.FullHelp() {
+			if enabledBindings := getEnabledBindings(group); len(enabledBindings) > 0 {
+				results = append(results, enabledBindings)
+			}
+		}
+	}
+	for _, group := range registry.globalBindingGroups {
+		if enabledBindings := getEnabledBindings(group); len(enabledBindings) > 0 {
+			results = append(results, enabledBindings)
+		}
+	}
+	return results
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func getEnabledBindings(bindings []key.Binding) []key.Binding {
+	results := make([]key.Binding, 0, len(bindings))
+	for _, binding := range bindings {
+		if binding.Enabled() {
+			results = append(results, binding)
+		}
+	}
+	return results
+}
@@ -0,0 +1,407 @@
+package bubble_bath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// BubbleTea doesn't export its key names, so they're recovered by asking each key type for its name
+// The range comfortably covers both the control keys (non-negative) and the special keys (negative)
+var keyTypeNames = func() map[string]bool {
+	result := map[string]bool{}
+	for keyTypeInt := -200; keyTypeInt <= 127; keyTypeInt++ {
+		if name := tea.KeyType(keyTypeInt).String(); name != "" {
+			result[name] = true
+		}
+	}
+	return result
+}()
+
+// RemappableKeyMap is a key map whose bindings can be overridden by a KeyMapConfig
+type RemappableKeyMap interface {
+	// GetBindingsByAction gets pointers to the key map's bindings, keyed by the action names used in config files (e.g.
+	// "page_down")
+	GetBindingsByAction() map[string]*key.Binding
+}
+
+// KeyRemappableComponent is a component whose bindings can be overridden by a KeyMapConfig
+// The program's config is applied to each KeyRemappableComponent when it's mounted (see MountTree), so bindings that
+// the app changes afterwards (e.g. with a SetKeyMap) stick
+type KeyRemappableComponent interface {
+	Component
+
+	ApplyKeyMapConfig(config *KeyMapConfig)
+}
+
+// ApplyKeyMapConfig applies the config to every KeyRemappableComponent in the tree rooted at the given component
+func ApplyKeyMapConfig(root Component, config *KeyMapConfig) {
+	if remappableComponent, ok := root.(KeyRemappableComponent); ok {
+		remappableComponent.ApplyKeyMapConfig(config)
+	}
+	for _, child := range GetChildren(root) {
+		ApplyKeyMapConfig(child, config)
+	}
+}
+
+// The default key maps of every key map type, for validating configs against
+var registeredKeyMaps = map[string]RemappableKeyMap{}
+
+// RegisterKeyMapType makes the key map type (e.g. "filterable_list") configurable, using the default key map to know
+// which actions exist and what they're bound to if the config doesn't override them
+// Components register their key map types when their packages are initialized
+func RegisterKeyMapType(keyMapType string, defaultKeyMap RemappableKeyMap) {
+	registeredKeyMaps[keyMapType] = defaultKeyMap
+}
+
+// Pairs of key map types whose bindings are active at the same time, for validating configs against
+var overlappingKeyMapTypes = map[[2]string]bool{}
+
+// RegisterOverlappingKeyMapTypes declares that the bindings of the two key map types are active at the same time (e.g.
+// because a component of one type handles keys on behalf of a component of the other that it contains), so that a
+// config binding a key in both gets rejected
+// The FocusManager's key map type overlaps with every other type, since it sees keys before any component does, so it
+// needn't be registered
+func RegisterOverlappingKeyMapTypes(keyMapType string, otherKeyMapType string) {
+	overlappingKeyMapTypes[getKeyMapTypePair(keyMapType, otherKeyMapType)] = true
+}
+
+type KeyMapConfigFormat string
+
+const (
+	KeyMapConfigFormatJSON KeyMapConfigFormat = "json"
+	KeyMapConfigFormatYAML KeyMapConfigFormat = "yaml"
+	KeyMapConfigFormatTOML KeyMapConfigFormat = "toml"
+)
+
+// KeyMapConfig overrides the bindings of key maps, keyed by key map type and then action name
+// In a config file each action maps to a key or list of keys (an empty list disables the action), e.g. in YAML:
+//
+//	filterable_list:
+//	  down: [down, j]
+//	  up: [up, k]
+//	filterable_checklist:
+//	  toggle_selection: " "
+//
+// Keys are written the way tea.KeyMsg.String() writes them (e.g. "enter", "ctrl+c", "alt+x", "j", or " " for the space
+// bar), since that's what bindings are matched against
+// Actions that aren't mentioned keep their bindings
+type KeyMapConfig struct {
+	// Key map type -> action -> keys
+	overrides map[string]map[string][]string
+}
+
+// LoadKeyMapConfig loads a config from a JSON, YAML, or TOML file, picking the format from the file's extension
+func LoadKeyMapConfig(filepathStr string) (*KeyMapConfig, error) {
+	var format KeyMapConfigFormat
+	switch strings.ToLower(filepath.Ext(filepathStr)) {
+	case ".json":
+		format = KeyMapConfigFormatJSON
+	case ".yaml", ".yml":
+		format = KeyMapConfigFormatYAML
+	case ".toml":
+		format = KeyMapConfigFormatTOML
+	default:
+		return nil, fmt.Errorf("Couldn't tell the format of key map config file '%v' from its extension; expected .json, .yaml, .yml, or .toml", filepathStr)
+	}
+
+	data, err := os.ReadFile(filepathStr)
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred reading key map config file '%v': %w", filepathStr, err)
+	}
+
+	config, err := ParseKeyMapConfig(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred parsing key map config file '%v': %w", filepathStr, err)
+	}
+	return config, nil
+}
+
+// ParseKeyMapConfig parses a config in the given format, returning an error if it refers to key map types, actions, or
+// keys that don't exist, if it leaves two actions of the same key map bound to the same key, or if it binds a key that's
+// already bound in an overlapping key map type (see RegisterOverlappingKeyMapTypes)
+func ParseKeyMapConfig(data []byte, format KeyMapConfigFormat) (*KeyMapConfig, error) {
+	rawConfig := map[string]interface{}{}
+	var err error
+	switch format {
+	case KeyMapConfigFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		err = decoder.Decode(&rawConfig)
+	case KeyMapConfigFormatYAML:
+		err = yaml.Unmarshal(data, &rawConfig)
+	case KeyMapConfigFormatTOML:
+		err = toml.Unmarshal(data, &rawConfig)
+	default:
+		return nil, fmt.Errorf("Unrecognized key map config format '%v'", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred decoding the key map config as %v: %w", format, err)
+	}
+
+	overrides := map[string]map[string][]string{}
+	for keyMapType, rawActions := range rawConfig {
+		defaultKeyMap, found := registeredKeyMaps[keyMapType]
+		if !found {
+			return nil, fmt.Errorf("Unknown key map type '%v'; known types are: %v", keyMapType, strings.Join(getRegisteredKeyMapTypes(), ", "))
+		}
+
+		rawActionsMap, ok := rawActions.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected key map type '%v' to map action names to keys, but got '%v'", keyMapType, rawActions)
+		}
+
+		defaultBindings := defaultKeyMap.GetBindingsByAction()
+		actionOverrides := map[string][]string{}
+		for action, rawKeys := range rawActionsMap {
+			if _, found := defaultBindings[action]; !found {
+				return nil, fmt.Errorf("Unknown action '%v' for key map type '%v'; known actions are: %v", action, keyMapType, strings.Join(getSortedActions(defaultBindings), ", "))
+			}
+			keys, err := parseKeys(rawKeys)
+			if err != nil {
+				return nil, fmt.Errorf("An error occurred parsing the keys for action '%v' of key map type '%v': %w", action, keyMapType, err)
+			}
+			actionOverrides[action] = keys
+		}
+
+		if err := validateNoConflicts(defaultBindings, actionOverrides); err != nil {
+			return nil, fmt.Errorf("Key map type '%v' has conflicting bindings: %w", keyMapType, err)
+		}
+		overrides[keyMapType] = actionOverrides
+	}
+
+	if err := validateNoOverlappingConflicts(overrides); err != nil {
+		return nil, fmt.Errorf("The key map config has conflicting bindings between key map types: %w", err)
+	}
+
+	return &KeyMapConfig{
+		overrides: overrides,
+	}, nil
+}
+
+// Apply overrides the key map's bindings with the ones configured for the key map type
+// The help text of each remapped binding is updated to show its first key
+func (config *KeyMapConfig) Apply(keyMapType string, keyMap RemappableKeyMap) {
+	actionOverrides, found := config.overrides[keyMapType]
+	if !found {
+		return
+	}
+
+	bindings := keyMap.GetBindingsByAction()
+	for action, keys := range actionOverrides {
+		binding, found := bindings[action]
+		if !found {
+			continue
+		}
+		*binding = getRemappedBinding(*binding, keys)
+	}
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// parseKeys accepts either a single key or a list of keys, since the decoders hand back generic values
+func parseKeys(rawKeys interface{}) ([]string, error) {
+	switch rawKeys := rawKeys.(type) {
+	case string:
+		return []string{rawKeys}, validateKeyNames([]string{rawKeys})
+	case []interface{}:
+		keys := make([]string, 0, len(rawKeys))
+		for _, rawKey := range rawKeys {
+			keyStr, ok := rawKey.(string)
+			if !ok {
+				return nil, fmt.Errorf("Expected key '%v' to be a string", rawKey)
+			}
+			keys = append(keys, keyStr)
+		}
+		return keys, validateKeyNames(keys)
+	}
+	return nil, fmt.Errorf("Expected a key or a list of keys, but got '%v'", rawKeys)
+}
+
+// validateKeyNames checks that each key is one that tea.KeyMsg.String() can produce, since a binding for any other key
+// (e.g. "space" rather than " ") would silently never match
+func validateKeyNames(keys []string) error {
+	for _, keyStr := range keys {
+		name := keyStr
+		if name != "alt+" && strings.HasPrefix(name, "alt+") {
+			name = strings.TrimPrefix(name, "alt+")
+		}
+		if keyTypeNames[name] || utf8.RuneCountInString(name) == 1 {
+			continue
+		}
+		return fmt.Errorf("Unknown key '%v'; keys are written the way BubbleTea names them, e.g. 'enter', 'ctrl+c', 'alt+x', 'j', or ' ' for the space bar", keyStr)
+	}
+	return nil
+}
+
+// validateNoConflicts checks that no key would trigger two actions once the overrides are applied on top of the
+// defaults
+func validateNoConflicts(defaultBindings map[string]*key.Binding, actionOverrides map[string][]string) error {
+	actionsByKey := map[string]string{}
+
+	// Sorted so the error is the same from run to run
+	for _, action := range getSortedActions(defaultBindings) {
+		binding := *defaultBindings[action]
+		if keys, found := actionOverrides[action]; found {
+			binding = getRemappedBinding(binding, keys)
+		}
+		if !binding.Enabled() {
+			continue
+		}
+
+		for _, keyStr := range binding.Keys() {
+			if otherAction, found := actionsByKey[keyStr]; found {
+				return fmt.Errorf("Key '%v' is bound to both '%v' and '%v'", keyStr, otherAction, action)
+			}
+			actionsByKey[keyStr] = action
+		}
+	}
+	return nil
+}
+
+// validateNoOverlappingConflicts checks that no key the config binds would trigger actions of two key map types that
+// are active at the same time
+// Only conflicts involving a configured binding are reported, since the defaults are the components' own business
+func validateNoOverlappingConflicts(overrides map[string]map[string][]string) error {
+	for _, pair := range getOverlappingKeyMapTypePairs() {
+		_, isFirstConfigured := overrides[pair[0]]
+		_, isSecondConfigured := overrides[pair[1]]
+		if !isFirstConfigured && !isSecondConfigured {
+			continue
+		}
+
+		firstActionsByKey := getActionsByKey(pair[0], overrides[pair[0]])
+		secondActionsByKey := getActionsByKey(pair[1], overrides[pair[1]])
+
+		// Sorted so the error is the same from run to run
+		for _, keyStr := range getSortedKeys(firstActionsByKey) {
+			firstAction := firstActionsByKey[keyStr]
+			secondAction, found := secondActionsByKey[keyStr]
+			if !found {
+				continue
+			}
+			_, isFirstOverridden := overrides[pair[0]][firstAction]
+			_, isSecondOverridden := overrides[pair[1]][secondAction]
+			if isFirstOverridden || isSecondOverridden {
+				return fmt.Errorf(
+					"Key '%v' is bound to both '%v' of key map type '%v' and '%v' of key map type '%v'",
+					keyStr,
+					firstAction,
+					pair[0],
+					secondAction,
+					pair[1],
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// getActionsByKey gets the action that each enabled key triggers in the key map type once the overrides are applied
+// on top of the defaults
+func getActionsByKey(keyMapType string, actionOverrides map[string][]string) map[string]string {
+	defaultBindings := registeredKeyMaps[keyMapType].GetBindingsByAction()
+	results := map[string]string{}
+	for action, bindingPtr := range defaultBindings {
+		binding := *bindingPtr
+		if keys, found := actionOverrides[action]; found {
+			binding = getRemappedBinding(binding, keys)
+		}
+		if !binding.Enabled() {
+			continue
+		}
+		for _, keyStr := range binding.Keys() {
+			results[keyStr] = action
+		}
+	}
+	return results
+}
+
+// getOverlappingKeyMapTypePairs gets the registered overlapping pairs, plus the focus key map type paired with every
+// other type, in sorted order
+func getOverlappingKeyMapTypePairs() [][2]string {
+	pairSet := map[[2]string]bool{}
+	for pair := range overlappingKeyMapTypes {
+		pairSet[pair] = true
+	}
+	if _, found := registeredKeyMaps[FocusKeyMapType]; found {
+		for keyMapType := range registeredKeyMaps {
+			if keyMapType != FocusKeyMapType {
+				pairSet[getKeyMapTypePair(FocusKeyMapType, keyMapType)] = true
+			}
+		}
+	}
+
+	results := make([][2]string, 0, len(pairSet))
+	for pair := range pairSet {
+		// Types that were never registered can't be configured, so there's nothing to check
+		_, isFirstRegistered := registeredKeyMaps[pair[0]]
+		_, isSecondRegistered := registeredKeyMaps[pair[1]]
+		if isFirstRegistered && isSecondRegistered {
+			results = append(results, pair)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i][0] != results[j][0] {
+			return results[i][0] < results[j][0]
+		}
+		return results[i][1] < results[j][1]
+	})
+	return results
+}
+
+// getKeyMapTypePair puts the two types in a canonical order, so that a pair is the same whichever way round it's given
+func getKeyMapTypePair(keyMapType string, otherKeyMapType string) [2]string {
+	if otherKeyMapType < keyMapType {
+		return [2]string{otherKeyMapType, keyMapType}
+	}
+	return [2]string{keyMapType, otherKeyMapType}
+}
+
+func getRemappedBinding(binding key.Binding, keys []string) key.Binding {
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], binding.Help().Desc),
+	)
+}
+
+func getRegisteredKeyMapTypes() []string {
+	results := make([]string, 0, len(registeredKeyMaps))
+	for keyMapType := range registeredKeyMaps {
+		results = append(results, keyMapType)
+	}
+	sort.Strings(results)
+	return results
+}
+
+func getSortedKeys(actionsByKey map[string]string) []string {
+	results := make([]string, 0, len(actionsByKey))
+	for keyStr := range actionsByKey {
+		results = append(results, keyStr)
+	}
+	sort.Strings(results)
+	return results
+}
+
+func getSortedActions(bindings map[string]*key.Binding) []string {
+	results := make([]string, 0, len(bindings))
+	for action := range bindings {
+		results = append(results, action)
+	}
+	sort.Strings(results)
+	return results
+}
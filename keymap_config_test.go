@@ -0,0 +1,310 @@
+package bubble_bath
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	testOuterKeyMapType = "test_outer"
+	testInnerKeyMapType = "test_inner"
+)
+
+// testKeyMap is a key map with a single action, for registering fake key map types
+type testKeyMap struct {
+	Act key.Binding
+}
+
+func (keyMap *testKeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"act": &keyMap.Act,
+	}
+}
+
+func init() {
+	RegisterKeyMapType(testOuterKeyMapType, &testKeyMap{Act: key.NewBinding(key.WithKeys("o"))})
+	RegisterKeyMapType(testInnerKeyMapType, &testKeyMap{Act: key.NewBinding(key.WithKeys("i"))})
+	RegisterOverlappingKeyMapTypes(testOuterKeyMapType, testInnerKeyMapType)
+}
+
+func TestParseKeyMapConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		data                   string
+		format                 KeyMapConfigFormat
+		expectedErrorSubstring string
+	}{
+		{
+			name:                   "unrecognized format",
+			data:                   "",
+			format:                 KeyMapConfigFormat("ini"),
+			expectedErrorSubstring: "Unrecognized key map config format 'ini'",
+		},
+		{
+			name:                   "malformed JSON",
+			data:                   `{"test_outer": `,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "decoding the key map config as json",
+		},
+		{
+			name:                   "malformed YAML",
+			data:                   "test_outer: [",
+			format:                 KeyMapConfigFormatYAML,
+			expectedErrorSubstring: "decoding the key map config as yaml",
+		},
+		{
+			name:                   "malformed TOML",
+			data:                   "[test_outer",
+			format:                 KeyMapConfigFormatTOML,
+			expectedErrorSubstring: "decoding the key map config as toml",
+		},
+		{
+			name:                   "unknown key map type",
+			data:                   `{"nonexistent": {"act": "x"}}`,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "Unknown key map type 'nonexistent'",
+		},
+		{
+			name:                   "key map type not mapped to actions",
+			data:                   `{"test_outer": "x"}`,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "Expected key map type 'test_outer' to map action names to keys",
+		},
+		{
+			name:                   "unknown action",
+			data:                   `{"test_outer": {"nonexistent": "x"}}`,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "Unknown action 'nonexistent' for key map type 'test_outer'; known actions are: act",
+		},
+		{
+			name:                   "non-string key",
+			data:                   `{"test_outer": {"act": ["x", 3]}}`,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "Expected key '3' to be a string",
+		},
+		{
+			name:                   "keys neither a string nor a list",
+			data:                   `{"test_outer": {"act": {"key": "x"}}}`,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "Expected a key or a list of keys",
+		},
+		{
+			name:                   "unknown key name",
+			data:                   "test_outer:\n  act: space",
+			format:                 KeyMapConfigFormatYAML,
+			expectedErrorSubstring: "Unknown key 'space'",
+		},
+		{
+			name:                   "unknown key name in a list",
+			data:                   `{"test_outer": {"act": ["x", "alt+pgdn"]}}`,
+			format:                 KeyMapConfigFormatJSON,
+			expectedErrorSubstring: "Unknown key 'alt+pgdn'",
+		},
+		{
+			name:                   "two actions of the same type bound to the same key",
+			data:                   "[focus]\nup = \"tab\"",
+			format:                 KeyMapConfigFormatTOML,
+			expectedErrorSubstring: "Key 'tab' is bound to both 'next' and 'up'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config, err := ParseKeyMapConfig([]byte(testCase.data), testCase.format)
+			if err == nil {
+				t.Fatalf("Expected an error containing '%v' but got config %+v", testCase.expectedErrorSubstring, config)
+			}
+			if !strings.Contains(err.Error(), testCase.expectedErrorSubstring) {
+				t.Errorf("Expected an error containing '%v' but got: %v", testCase.expectedErrorSubstring, err)
+			}
+		})
+	}
+}
+
+func TestParseKeyMapConfigAcceptsKeyNames(t *testing.T) {
+	testCases := []string{" ", "alt+ ", "j", "+", "é", "alt+x", "enter", "ctrl+c", "shift+left", "pgdown", "f12"}
+
+	for _, keyStr := range testCases {
+		t.Run(keyStr, func(t *testing.T) {
+			data, err := json.Marshal(map[string]map[string]string{testOuterKeyMapType: {"act": keyStr}})
+			if err != nil {
+				t.Fatalf("An error occurred marshalling the test config: %v", err)
+			}
+			if _, err := ParseKeyMapConfig(data, KeyMapConfigFormatJSON); err != nil {
+				t.Errorf("Expected key '%v' to be accepted, but got: %v", keyStr, err)
+			}
+		})
+	}
+}
+
+func TestLoadKeyMapConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		filepath               string
+		expectedErrorSubstring string
+	}{
+		{
+			name:                   "unknown extension",
+			filepath:               "keymap.ini",
+			expectedErrorSubstring: "Couldn't tell the format of key map config file 'keymap.ini'",
+		},
+		{
+			name:                   "missing file",
+			filepath:               filepath.Join(t.TempDir(), "keymap.yaml"),
+			expectedErrorSubstring: "An error occurred reading key map config file",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := LoadKeyMapConfig(testCase.filepath)
+			if err == nil {
+				t.Fatalf("Expected an error containing '%v' but got none", testCase.expectedErrorSubstring)
+			}
+			if !strings.Contains(err.Error(), testCase.expectedErrorSubstring) {
+				t.Errorf("Expected an error containing '%v' but got: %v", testCase.expectedErrorSubstring, err)
+			}
+		})
+	}
+}
+
+func TestParseKeyMapConfigOverlappingConflicts(t *testing.T) {
+	testCases := []struct {
+		name string
+		yaml string
+		// Empty if the config should parse
+		expectedErrorSubstring string
+	}{
+		{
+			name:                   "nested type bound to the outer type's key",
+			yaml:                   "test_inner:\n  act: o",
+			expectedErrorSubstring: "Key 'o' is bound to both 'act' of key map type 'test_inner' and 'act' of key map type 'test_outer'",
+		},
+		{
+			name:                   "both types bound to the same key",
+			yaml:                   "test_inner:\n  act: x\ntest_outer:\n  act: x",
+			expectedErrorSubstring: "Key 'x'",
+		},
+		{
+			name:                   "component type bound to a focus key",
+			yaml:                   "test_outer:\n  act: tab",
+			expectedErrorSubstring: "Key 'tab' is bound to both 'next' of key map type 'focus'",
+		},
+		{
+			name:                   "focus bound to a component type's key",
+			yaml:                   "focus:\n  next: o",
+			expectedErrorSubstring: "Key 'o'",
+		},
+		{
+			name:                   "swapping keys between overlapping types",
+			yaml:                   "test_inner:\n  act: o\ntest_outer:\n  act: i",
+			expectedErrorSubstring: "",
+		},
+		{
+			name:                   "disabling the conflicting binding",
+			yaml:                   "test_inner:\n  act: o\ntest_outer:\n  act: []",
+			expectedErrorSubstring: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ParseKeyMapConfig([]byte(testCase.yaml), KeyMapConfigFormatYAML)
+			if testCase.expectedErrorSubstring == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error containing '%v' but got none", testCase.expectedErrorSubstring)
+			}
+			if !strings.Contains(err.Error(), testCase.expectedErrorSubstring) {
+				t.Errorf("Expected an error containing '%v' but got: %v", testCase.expectedErrorSubstring, err)
+			}
+		})
+	}
+}
+
+// remappableFake is an app whose key map is of the outer test type
+type remappableFake struct {
+	keyMap testKeyMap
+}
+
+func (fake *remappableFake) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (fake *remappableFake) View() string {
+	return ""
+}
+
+func (fake *remappableFake) Resize(width int, height int) {}
+
+func (fake *remappableFake) GetWidth() int {
+	return 0
+}
+
+func (fake *remappableFake) GetHeight() int {
+	return 0
+}
+
+func (fake *remappableFake) SetFocus(isFocused bool) tea.Cmd {
+	return nil
+}
+
+func (fake *remappableFake) IsFocused() bool {
+	return false
+}
+
+func (fake *remappableFake) ApplyKeyMapConfig(config *KeyMapConfig) {
+	config.Apply(testOuterKeyMapType, &fake.keyMap)
+}
+
+func TestKeyMapConfigIsAppliedOnMount(t *testing.T) {
+	config, err := ParseKeyMapConfig([]byte("test_outer:\n  act: z"), KeyMapConfigFormatYAML)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	app := &remappableFake{keyMap: testKeyMap{Act: key.NewBinding(key.WithKeys("o"))}}
+	model := NewBubbleBathModel(app, WithKeyMapConfig(config), WithTheme(DarkTheme))
+	model.Init()
+	if keys := app.keyMap.Act.Keys(); !reflect.DeepEqual(keys, []string{"z"}) {
+		t.Fatalf("Expected the config to be applied on mount, but the keys are %v", keys)
+	}
+
+	// Rebinding at runtime should survive later resizes & renders
+	app.keyMap.Act = key.NewBinding(key.WithKeys("q"))
+	model.Update(tea.WindowSizeMsg{Width: 10, Height: 10})
+	model.View()
+	if keys := app.keyMap.Act.Keys(); !reflect.DeepEqual(keys, []string{"q"}) {
+		t.Errorf("Expected the runtime binding to stick, but the keys are %v", keys)
+	}
+}
+
+func TestKeyMapConfigIsKeptPerProgram(t *testing.T) {
+	newStackWithConfig := func(keyStr string) *OverlayStack {
+		config, err := ParseKeyMapConfig([]byte("test_outer:\n  act: "+keyStr), KeyMapConfigFormatYAML)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		stack := NewOverlayStack(&remappableFake{keyMap: testKeyMap{Act: key.NewBinding(key.WithKeys("o"))}})
+		NewBubbleBathModel(stack, WithKeyMapConfig(config), WithTheme(DarkTheme)).Init()
+		return stack
+	}
+	firstStack := newStackWithConfig("x")
+	newStackWithConfig("y")
+
+	// Mounted after the second program started, but should still get the first program's config
+	overlay := &remappableFake{keyMap: testKeyMap{Act: key.NewBinding(key.WithKeys("o"))}}
+	firstStack.Push(Overlay{Component: overlay, Position: OverlayCentered(), Width: 1, Height: 1})
+	if keys := overlay.keyMap.Act.Keys(); !reflect.DeepEqual(keys, []string{"x"}) {
+		t.Errorf("Expected the overlay to get its own program's config, but the keys are %v", keys)
+	}
+}
@@ -0,0 +1,9 @@
+package bubble_bath
+
+// Rectangle is a region of the terminal, measured in cells
+type Rectangle struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
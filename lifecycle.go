@@ -0,0 +1,94 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// MountContext is the program-wide setup that components are given as they're mounted
+// Containers that mount children after they themselves were mounted should keep the context they were mounted with (see
+// Mounter) and pass it along to MountTree, so that the new children get the same setup
+type MountContext struct {
+	// Applied to each KeyRemappableComponent as it's mounted; nil if the app's bindings aren't being overridden
+	KeyMapConfig *KeyMapConfig
+
+	// Told about every mount, so that the program styles the new components before they're rendered; nil outside of a
+	// program
+	styleTracker *styleTracker
+}
+
+// Mounter is a component that needs to do something when it enters the component tree, e.g. starting a timer,
+// subscribing to something, or kicking off an async load
+// This is the place for the work that tea.Model.Init would do, since Init is called on the top-level model only
+type Mounter interface {
+	Component
+
+	// Mount is called when the component enters the tree (which, for components in the tree when the program starts,
+	// is when the program starts), after all its descendants have been mounted
+	Mount(ctx MountContext) tea.Cmd
+}
+
+// Unmounter is a component that needs to clean up when it leaves the component tree, e.g. stopping a timer or
+// cancelling an async load
+type Unmounter interface {
+	Component
+
+	// Unmount is called when the component leaves the tree, before any of its descendants are unmounted
+	Unmount() tea.Cmd
+}
+
+// HiddenChildrenContainerComponent is a ContainerComponent whose GetChildren leaves out children that are still in the
+// tree (e.g. the screens beneath a router's active one, or the base beneath an OverlayStack's overlays), so that they
+// get mounted & unmounted along with everything else
+type HiddenChildrenContainerComponent interface {
+	ContainerComponent
+
+	// GetHiddenChildren gets the children that aren't returned by GetChildren
+	GetHiddenChildren() []Component
+}
+
+// MountTree mounts the component and all of its descendants (children first), batching the commands they return
+// Any KeyRemappableComponents get the context's KeyMapConfig applied before they're mounted
+// Containers should call it on each child that's added while they themselves are mounted; to know whether they are (and
+// with what context), they'll need to be Mounters & Unmounters too
+func MountTree(root Component, ctx MountContext) tea.Cmd {
+	mustBePointer(root)
+
+	cmds := make([]tea.Cmd, 0)
+	for _, child := range getAllChildren(root) {
+		cmds = append(cmds, MountTree(child, ctx))
+	}
+	if remappableComponent, ok := root.(KeyRemappableComponent); ok && ctx.KeyMapConfig != nil {
+		remappableComponent.ApplyKeyMapConfig(ctx.KeyMapConfig)
+	}
+	if mounter, ok := root.(Mounter); ok {
+		cmds = append(cmds, mounter.Mount(ctx))
+	}
+	if ctx.styleTracker != nil {
+		ctx.styleTracker.isStale = true
+	}
+	return tea.Batch(cmds...)
+}
+
+// UnmountTree unmounts the component and all of its descendants (parents first), batching the commands they return
+// Containers should call it on each child that's removed while they themselves are mounted
+func UnmountTree(root Component) tea.Cmd {
+	cmds := make([]tea.Cmd, 0)
+	if unmounter, ok := root.(Unmounter); ok {
+		cmds = append(cmds, unmounter.Unmount())
+	}
+	for _, child := range getAllChildren(root) {
+		cmds = append(cmds, UnmountTree(child))
+	}
+	return tea.Batch(cmds...)
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// getAllChildren gets both the children returned by GetChildren and the hidden ones
+func getAllChildren(component Component) []Component {
+	result := GetChildren(component)
+	if container, ok := component.(HiddenChildrenContainerComponent); ok {
+		result = append(append([]Component{}, result...), container.GetHiddenChildren()...)
+	}
+	return result
+}
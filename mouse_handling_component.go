@@ -0,0 +1,14 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// MouseHandlingComponent is implemented by components that react to the mouse
+type MouseHandlingComponent interface {
+	Component
+
+	// HandleMouse handles a mouse event whose X & Y have already been translated to be relative to the component's
+	// top-left corner
+	// During a drag the component keeps receiving events even after the pointer leaves it, so coordinates can be negative
+	// or beyond the component's size
+	HandleMouse(msg tea.MouseMsg) tea.Cmd
+}
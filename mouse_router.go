@@ -0,0 +1,129 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// MouseRouter delivers mouse events to the MouseHandlingComponent under the pointer, hit-testing against the
+// rectangles reported by the LayoutContainerComponents in the tree
+//
+// When a button is pressed the component under the pointer captures the mouse, so that the rest of the drag (and the
+// eventual release) goes to it no matter where the pointer moves
+// If a FocusManager is given, clicking a focusable component also focuses it
+type MouseRouter struct {
+	root Component
+
+	// Will be nil if clicking shouldn't move focus
+	focusManager *FocusManager
+
+	// The component that's receiving all mouse events until the button is released, or nil if no button is held
+	capturingComponent MouseHandlingComponent
+}
+
+func NewMouseRouter(root Component, focusManager *FocusManager) *MouseRouter {
+	return &MouseRouter{
+		root:               root,
+		focusManager:       focusManager,
+		capturingComponent: nil,
+	}
+}
+
+// HandleMouse routes the mouse event (whose coordinates are relative to the root) to the right component, returning
+// true if some component handled it
+// Events that nothing under the pointer handles still move focus if they're clicks, but are reported unhandled
+func (router *MouseRouter) HandleMouse(msg tea.MouseMsg) (tea.Cmd, bool) {
+	absoluteRectangles := GetAbsoluteRectangles(router.root)
+
+	if router.capturingComponent != nil {
+		target := router.capturingComponent
+		if msg.Type == tea.MouseRelease {
+			router.capturingComponent = nil
+		}
+		return target.HandleMouse(translateMouseMsg(msg, absoluteRectangles[target])), true
+	}
+
+	path := getComponentPathAtPoint(router.root, absoluteRectangles, msg.X, msg.Y)
+
+	cmds := make([]tea.Cmd, 0)
+	isPress := msg.Type == tea.MouseLeft || msg.Type == tea.MouseMiddle || msg.Type == tea.MouseRight
+	if isPress && router.focusManager != nil {
+		cmds = append(cmds, router.focusDeepestFocusTarget(path))
+	}
+
+	// The deepest component that handles the mouse gets the event
+	var target MouseHandlingComponent
+	for idx := len(path) - 1; idx >= 0; idx-- {
+		if handler, ok := path[idx].(MouseHandlingComponent); ok {
+			target = handler
+			break
+		}
+	}
+	if target == nil {
+		return tea.Batch(cmds...), false
+	}
+
+	if isPress {
+		router.capturingComponent = target
+	}
+	cmds = append(cmds, target.HandleMouse(translateMouseMsg(msg, absoluteRectangles[target])))
+	return tea.Batch(cmds...), true
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// Focuses the deepest component on the path that the focus manager considers focusable (if any)
+func (router *MouseRouter) focusDeepestFocusTarget(path []Component) tea.Cmd {
+	focusTargets := map[Component]bool{}
+	for _, targetPath := range router.focusManager.getFocusTargetPaths() {
+		focusTargets[getPathTarget(targetPath)] = true
+	}
+
+	for idx := len(path) - 1; idx >= 0; idx-- {
+		if !focusTargets[path[idx]] {
+			continue
+		}
+		target := path[idx].(InteractiveComponent)
+		if target == router.focusManager.GetFocused() {
+			return nil
+		}
+		return router.focusManager.Focus(target)
+	}
+	return nil
+}
+
+// Gets the path from the root to the deepest component containing the point, or nil if the point is outside the root
+// When siblings overlap the later one wins, since it's the one drawn on top
+func getComponentPathAtPoint(root Component, absoluteRectangles map[Component]Rectangle, x int, y int) []Component {
+	if !isPointInRectangle(absoluteRectangles[root], x, y) {
+		return nil
+	}
+
+	path := []Component{root}
+	current := root
+	for {
+		var next Component
+		children := GetChildren(current)
+		for idx := len(children) - 1; idx >= 0; idx-- {
+			if isPointInRectangle(absoluteRectangles[children[idx]], x, y) {
+				next = children[idx]
+				break
+			}
+		}
+		if next == nil {
+			return path
+		}
+		path = append(path, next)
+		current = next
+	}
+}
+
+func isPointInRectangle(rectangle Rectangle, x int, y int) bool {
+	return x >= rectangle.X && x < rectangle.X+rectangle.Width &&
+		y >= rectangle.Y && y < rectangle.Y+rectangle.Height
+}
+
+func translateMouseMsg(msg tea.MouseMsg, rectangle Rectangle) tea.MouseMsg {
+	msg.X -= rectangle.X
+	msg.Y -= rectangle.Y
+	return msg
+}
@@ -0,0 +1,297 @@
+package bubble_bath
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OverlayPosition is where an overlay sits over the base component
+type OverlayPosition struct {
+	isCentered bool
+
+	// Only used if the overlay isn't centered
+	x int
+	y int
+}
+
+// OverlayCentered centers the overlay over the base component
+func OverlayCentered() OverlayPosition {
+	return OverlayPosition{
+		isCentered: true,
+		x:          0,
+		y:          0,
+	}
+}
+
+// OverlayAt puts the overlay's top-left corner at the given position, relative to the base component's top-left corner
+func OverlayAt(x int, y int) OverlayPosition {
+	return OverlayPosition{
+		isCentered: false,
+		x:          x,
+		y:          y,
+	}
+}
+
+// Overlay is a component drawn on top of everything beneath it in an OverlayStack (e.g. a dialog)
+type Overlay struct {
+	Component InteractiveComponent
+
+	Position OverlayPosition
+
+	// The overlay's size; 0 means the component's intrinsic size (see IntrinsicallySizedComponent)
+	// The overlay gets clamped to fit within the stack either way
+	Width  int
+	Height int
+}
+
+// PushOverlayMsg asks the OverlayStack to open the overlay on top of the others
+type PushOverlayMsg struct {
+	Overlay Overlay
+}
+
+// PopOverlayMsg asks the OverlayStack to close the topmost overlay
+type PopOverlayMsg struct{}
+
+// PushOverlay is a tea.Cmd factory that components anywhere in the tree can use to open an overlay
+func PushOverlay(overlay Overlay) tea.Cmd {
+	return func() tea.Msg {
+		return PushOverlayMsg{Overlay: overlay}
+	}
+}
+
+// PopOverlay is a tea.Cmd that closes the topmost overlay (e.g. for a dialog to close itself)
+func PopOverlay() tea.Msg {
+	return PopOverlayMsg{}
+}
+
+// OverlayStack draws a stack of overlays (e.g. dialogs) on top of a base component, with the most recently opened
+// overlay on top
+// The overlays are composited cell-by-cell on a Canvas, so the base's styling around each overlay is preserved
+//
+// The topmost overlay is modal: it gets all the key presses & mouse events, and it's the stack's only child so focus
+// managers & mouse routing can't reach anything beneath it
+// Focus moves to each overlay as it's opened, and back to whatever was beneath it when it's closed
+// Other messages go to everything in the stack, so that overlays beneath the top (and the base) keep running
+//
+// Overlays are mounted when they're opened and unmounted when they're closed (see Mounter)
+type OverlayStack struct {
+	base InteractiveComponent
+
+	// The overlays, from bottom to top
+	overlays []Overlay
+
+	// The rectangle of each overlay as of the last resize, relative to the stack's top-left corner
+	overlayRectangles []Rectangle
+
+	// Overlays only get mounted if the stack itself is, with the context the stack was mounted with
+	isMounted    bool
+	mountContext MountContext
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+func NewOverlayStack(base InteractiveComponent) *OverlayStack {
+	return &OverlayStack{
+		base:              base,
+		overlays:          make([]Overlay, 0),
+		overlayRectangles: make([]Rectangle, 0),
+		isMounted:         false,
+		mountContext:      MountContext{KeyMapConfig: nil, styleTracker: nil},
+		isFocused:         false,
+		width:             0,
+		height:            0,
+	}
+}
+
+// Push opens the overlay on top of the others, moving focus to it
+func (stack *OverlayStack) Push(overlay Overlay) tea.Cmd {
+	cmds := []tea.Cmd{}
+	if stack.isFocused {
+		cmds = append(cmds, stack.getTopComponent().SetFocus(false))
+	}
+
+	stack.overlays = append(stack.overlays, overlay)
+	stack.Resize(stack.width, stack.height)
+
+	if stack.isMounted {
+		cmds = append(cmds, MountTree(overlay.Component, stack.mountContext))
+	}
+
+	if stack.isFocused {
+		cmds = append(cmds, overlay.Component.SetFocus(true))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Pop closes the topmost overlay (if there is one), moving focus back to whatever is beneath it
+func (stack *OverlayStack) Pop() tea.Cmd {
+	if len(stack.overlays) == 0 {
+		return nil
+	}
+
+	cmds := []tea.Cmd{}
+	if stack.isFocused {
+		cmds = append(cmds, stack.getTopComponent().SetFocus(false))
+	}
+
+	popped := stack.overlays[len(stack.overlays)-1]
+	stack.overlays = stack.overlays[:len(stack.overlays)-1]
+	stack.overlayRectangles = stack.overlayRectangles[:len(stack.overlayRectangles)-1]
+
+	if stack.isMounted {
+		cmds = append(cmds, UnmountTree(popped.Component))
+	}
+
+	if stack.isFocused {
+		cmds = append(cmds, stack.getTopComponent().SetFocus(true))
+	}
+	return tea.Batch(cmds...)
+}
+
+// GetOverlays gets the open overlays, from bottom to top
+func (stack *OverlayStack) GetOverlays() []Overlay {
+	return stack.overlays
+}
+
+func (stack *OverlayStack) GetBase() InteractiveComponent {
+	return stack.base
+}
+
+func (stack *OverlayStack) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case PushOverlayMsg:
+		return stack.Push(msg.Overlay)
+	case PopOverlayMsg:
+		return stack.Pop()
+	case tea.KeyMsg, tea.MouseMsg:
+		return stack.getTopComponent().Update(msg)
+	}
+
+	cmds := []tea.Cmd{stack.base.Update(msg)}
+	for _, overlay := range stack.overlays {
+		cmds = append(cmds, overlay.Component.Update(msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (stack *OverlayStack) View() string {
+	return RenderDrawable(stack)
+}
+
+// Draw draws the base and then each overlay on top of it, blanking each overlay's region first so that nothing beneath
+// shows through
+func (stack *OverlayStack) Draw(canvas *Canvas) {
+	DrawComponent(canvas, stack.base)
+	for idx, overlay := range stack.overlays {
+		overlayCanvas := canvas.SubCanvas(stack.overlayRectangles[idx])
+		overlayCanvas.Clear()
+		DrawComponent(overlayCanvas, overlay.Component)
+	}
+}
+
+func (stack *OverlayStack) Resize(width int, height int) {
+	stack.width = width
+	stack.height = height
+	stack.base.Resize(width, height)
+
+	stack.overlayRectangles = make([]Rectangle, len(stack.overlays))
+	for idx, overlay := range stack.overlays {
+		rectangle := stack.calculateOverlayRectangle(overlay)
+		overlay.Component.Resize(rectangle.Width, rectangle.Height)
+		stack.overlayRectangles[idx] = rectangle
+	}
+}
+
+func (stack *OverlayStack) GetWidth() int {
+	return stack.width
+}
+
+func (stack *OverlayStack) GetHeight() int {
+	return stack.height
+}
+
+func (stack *OverlayStack) SetFocus(isFocused bool) tea.Cmd {
+	stack.isFocused = isFocused
+	return stack.getTopComponent().SetFocus(isFocused)
+}
+
+func (stack *OverlayStack) IsFocused() bool {
+	return stack.isFocused
+}
+
+// GetChildren gets only the topmost component, since nothing beneath a modal overlay should be reachable
+func (stack *OverlayStack) GetChildren() []Component {
+	return []Component{stack.getTopComponent()}
+}
+
+// GetHiddenChildren gets the base and any overlays beneath the topmost one
+func (stack *OverlayStack) GetHiddenChildren() []Component {
+	if len(stack.overlays) == 0 {
+		return nil
+	}
+	result := []Component{stack.base}
+	for _, overlay := range stack.overlays[:len(stack.overlays)-1] {
+		result = append(result, overlay.Component)
+	}
+	return result
+}
+
+func (stack *OverlayStack) Mount(ctx MountContext) tea.Cmd {
+	stack.isMounted = true
+	stack.mountContext = ctx
+	return nil
+}
+
+func (stack *OverlayStack) Unmount() tea.Cmd {
+	stack.isMounted = false
+	return nil
+}
+
+func (stack *OverlayStack) GetChildRectangles() []Rectangle {
+	if len(stack.overlays) == 0 {
+		return []Rectangle{{X: 0, Y: 0, Width: stack.width, Height: stack.height}}
+	}
+	return []Rectangle{stack.overlayRectangles[len(stack.overlayRectangles)-1]}
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// getTopComponent gets the topmost overlay's component, or the base if there are no overlays
+func (stack *OverlayStack) getTopComponent() InteractiveComponent {
+	if len(stack.overlays) == 0 {
+		return stack.base
+	}
+	return stack.overlays[len(stack.overlays)-1].Component
+}
+
+func (stack *OverlayStack) calculateOverlayRectangle(overlay Overlay) Rectangle {
+	width := overlay.Width
+	if width == 0 {
+		width = GetMaximumIntrinsicWidth(overlay.Component)
+	}
+	width = Clamp(width, 0, stack.width)
+
+	height := overlay.Height
+	if height == 0 {
+		height = GetHeightGivenWidth(overlay.Component, width)
+	}
+	height = Clamp(height, 0, stack.height)
+
+	if overlay.Position.isCentered {
+		return Rectangle{
+			X:      (stack.width - width) / 2,
+			Y:      (stack.height - height) / 2,
+			Width:  width,
+			Height: height,
+		}
+	}
+	return Rectangle{
+		X:      overlay.Position.x,
+		Y:      overlay.Position.y,
+		Width:  width,
+		Height: height,
+	}
+}
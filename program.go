@@ -1,6 +1,10 @@
 package bubble_bath
 
 import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -18,6 +22,84 @@ func WithQuitSequences(quitSequenceSet map[string]bool) BubbleBathOption {
 	}
 }
 
+// WithFocusManagement enables a FocusManager over the app's component tree, which moves focus between the focusable
+// components using the given keys
+func WithFocusManagement(keyMap FocusKeyMap) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.focusManager = NewFocusManager(model.appComponent, keyMap)
+	}
+}
+
+// WithMouseRouting delivers mouse events to the component under the pointer (see MouseRouter) rather than passing them
+// to the app component (which only gets the events that nothing under the pointer handles), and makes clicking a
+// component focus it if focus management is enabled
+// The program must also be run with one of BubbleTea's mouse options (e.g. tea.WithMouseCellMotion) to receive events
+func WithMouseRouting() BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.isMouseRoutingEnabled = true
+	}
+}
+
+// WithStyleSheet sets the style sheet that styles the app's StylableComponents, in place of the default style sheet
+// The sheet is used as-is regardless of the theme; use WithThemedStyleSheet for a sheet whose colors follow the theme
+func WithStyleSheet(sheet *StyleSheet) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.styleSheetFactory = func(theme Theme) *StyleSheet {
+			return sheet
+		}
+	}
+}
+
+// WithThemedStyleSheet sets the function that builds the style sheet from the app's theme, in place of
+// NewDefaultStyleSheet (e.g. to extend the default sheet with the app's own rules)
+func WithThemedStyleSheet(factory func(theme Theme) *StyleSheet) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.styleSheetFactory = factory
+	}
+}
+
+// WithTheme sets the theme that the app's ThemedComponents and style sheet derive their colors from, in place of the
+// theme picked by DetectTheme
+func WithTheme(theme Theme) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.theme = theme
+		model.isThemeSet = true
+	}
+}
+
+// WithKeyBindingRegistry adds the program's own bindings (quitting, and moving focus if focus management is enabled) to
+// the registry as app-wide bindings, so that help generated from the registry covers them too
+func WithKeyBindingRegistry(registry *KeyBindingRegistry) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.keyBindingRegistry = registry
+	}
+}
+
+// WithKeyMapConfig overrides the bindings of the app's components (and of the FocusManager, if focus management is
+// enabled) with those in the config (see LoadKeyMapConfig)
+func WithKeyMapConfig(config *KeyMapConfig) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.keyMapConfig = config
+	}
+}
+
+// WithEventBubbling dispatches key presses along the focused path (see DispatchEvent) rather than passing them to the
+// app component, so that the most deeply focused component gets the first chance to handle each one
+// Key presses that no component handles are passed to the app component as before, so components that don't implement
+// EventHandler still get them
+func WithEventBubbling() BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.isEventBubblingEnabled = true
+	}
+}
+
+// WithBus makes the program deliver events published with Publish to the bus' subscribers
+func WithBus(bus *Bus) BubbleBathOption {
+	return func(model *bubbleBathModel) {
+		model.bus = bus
+	}
+}
+
 var defaultQuitSequenceSet = map[string]bool{
 	"ctrl+c": true,
 	"ctrl+d": true,
@@ -30,49 +112,225 @@ type bubbleBathModel struct {
 	// Sequences matching String() of tea.KeyMsg that will quit the program
 	quitSequenceSet map[string]bool
 
+	// Will be nil if focus management isn't enabled
+	focusManager *FocusManager
+
+	isMouseRoutingEnabled bool
+
+	isEventBubblingEnabled bool
+
+	// Will be nil if mouse routing isn't enabled
+	mouseRouter *MouseRouter
+
+	// Retained between frames & only reallocated when the terminal is resized, and only used if the app component is a
+	// DrawableComponent
+	// Will be nil until the terminal size is known
+	canvas *Canvas
+
+	theme Theme
+
+	// Whether the theme was set with WithTheme, rather than needing to be detected
+	isThemeSet bool
+
+	styleSheetFactory func(theme Theme) *StyleSheet
+
+	// Will be nil if the app's bindings aren't being overridden
+	keyMapConfig *KeyMapConfig
+
+	// Will be nil if the app didn't give the program a registry
+	keyBindingRegistry *KeyBindingRegistry
+
+	// Will be nil if the app didn't give the program a bus
+	bus *Bus
+
+	// Drives every component's Animations
+	animationTicker *animationTicker
+
+	// Built from the theme by the style sheet factory
+	styleSheet *StyleSheet
+
+	// Records when the theme & style sheet need applying again, so that it's done once per change rather than on every
+	// render
+	styleTracker *styleTracker
+
 	appComponent InteractiveComponent
 }
 
 // NewBubbleBathModel creates a new tea.Model for tea.NewProgram based off the given InteractiveComponent
 func NewBubbleBathModel(app InteractiveComponent, options ...BubbleBathOption) tea.Model {
 	result := &bubbleBathModel{
-		initCmd:         nil,
-		quitSequenceSet: defaultQuitSequenceSet,
-		appComponent:    app,
+		initCmd:                nil,
+		quitSequenceSet:        defaultQuitSequenceSet,
+		focusManager:           nil,
+		isMouseRoutingEnabled:  false,
+		isEventBubblingEnabled: false,
+		mouseRouter:            nil,
+		canvas:                 nil,
+		theme:                  Theme{},
+		isThemeSet:             false,
+		styleSheetFactory:      NewDefaultStyleSheet,
+		styleSheet:             nil,
+		styleTracker:           &styleTracker{isStale: true},
+		keyMapConfig:           nil,
+		keyBindingRegistry:     nil,
+		bus:                    nil,
+		animationTicker:        newAnimationTicker(),
+		appComponent:           app,
 	}
 	for _, opt := range options {
 		opt(result)
 	}
+
+	// Done after the options so that the router can use the focus manager regardless of option order
+	if result.isMouseRoutingEnabled {
+		result.mouseRouter = NewMouseRouter(app, result.focusManager)
+	}
+
+	// Detection queries the terminal, so it's only done if the app didn't pick a theme
+	if !result.isThemeSet {
+		result.theme = DetectTheme()
+	}
+	result.styleSheet = result.styleSheetFactory(result.theme)
+
+	if result.keyMapConfig != nil && result.focusManager != nil {
+		result.keyMapConfig.Apply(FocusKeyMapType, &result.focusManager.KeyMap)
+	}
+
+	// Also done after the options, so that the registry gets the final quit sequences & focus key map
+	if result.keyBindingRegistry != nil {
+		if len(result.quitSequenceSet) > 0 {
+			result.keyBindingRegistry.AddGlobalBindings(getQuitBinding(result.quitSequenceSet))
+		}
+		if result.focusManager != nil {
+			for _, group := range result.focusManager.KeyMap.FullHelp() {
+				result.keyBindingRegistry.AddGlobalBindings(group...)
+			}
+		}
+	}
 	return result
 }
 
+// Init mounts the whole component tree (see Mounter), focuses the first focusable component if focus management is
+// enabled, and styles the tree
 func (b bubbleBathModel) Init() tea.Cmd {
-	return b.initCmd
+	mountContext := MountContext{
+		KeyMapConfig: b.keyMapConfig,
+		styleTracker: b.styleTracker,
+	}
+	cmds := []tea.Cmd{b.initCmd, MountTree(b.appComponent, mountContext)}
+	if b.focusManager != nil {
+		cmds = append(cmds, b.focusManager.Init())
+	}
+	b.applyStyles()
+	return tea.Batch(cmds...)
 }
 
+// Update handles the message, then re-styles the tree if the message may have changed the states that styles depend on
+// (e.g. focus moving, or a list's highlight) or components were mounted while handling it
+// Frequent messages like animation frames don't re-style the tree, so it's only walked when something's likely changed
 func (b bubbleBathModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := b.handleMsg(msg)
+	if doesMsgChangeStyleStates(msg) {
+		updated.styleTracker.isStale = true
+	}
+	if updated.styleTracker.isStale {
+		updated.applyStyles()
+	}
+	return updated, cmd
+}
+
+// View draws the app into the canvas and serializes it if the app is a DrawableComponent, and uses the app's View
+// otherwise
+// The tree is already styled by this point (see Update), so rendering has no side effects
+func (b bubbleBathModel) View() string {
+	drawableApp, ok := b.appComponent.(DrawableComponent)
+	if !ok || b.canvas == nil {
+		return b.appComponent.View()
+	}
+
+	b.canvas.Clear()
+	drawableApp.Draw(b.canvas)
+	return b.canvas.String()
+}
+
+func (b bubbleBathModel) handleMsg(msg tea.Msg) (bubbleBathModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if _, found := b.quitSequenceSet[msg.String()]; found {
 			return b, tea.Quit
 
 		}
+		if b.focusManager != nil {
+			if cmd, handled := b.focusManager.HandleKey(msg); handled {
+				return b, cmd
+			}
+		}
+		if b.isEventBubblingEnabled {
+			if cmd, handled := DispatchEvent(b.appComponent, msg); handled {
+				return b, cmd
+			}
+		}
+	case RequestFocusMsg:
+		if b.focusManager != nil {
+			return b, b.focusManager.Focus(msg.Target)
+		}
+	case ChangeThemeMsg:
+		previousTheme := b.theme
+		b.theme = msg.Theme
+		b.styleSheet = b.styleSheetFactory(msg.Theme)
+		return b, b.appComponent.Update(ThemeChangedMsg{
+			Previous: previousTheme,
+			Current:  msg.Theme,
+		})
+	case TaskProgressMsg:
+		// Only listened for again once this message is delivered, so that the task's messages can't arrive out of order
+		return b, tea.Batch(b.appComponent.Update(msg), msg.Task.waitForEvent())
+	case animationStartedMsg:
+		return b, b.animationTicker.register(msg.animation)
+	case animationTickMsg:
+		frame, nextTickCmd := b.animationTicker.tick(msg)
+		if len(frame.dueAnimations) == 0 {
+			return b, nextTickCmd
+		}
+		return b, tea.Batch(AnimateTree(b.appComponent, frame), nextTickCmd)
+	case PublishedMsg:
+		if b.bus != nil {
+			return b, b.bus.Deliver(msg)
+		}
+	case tea.MouseMsg:
+		if b.mouseRouter != nil {
+			cmd, handled := b.mouseRouter.HandleMouse(msg)
+			if handled {
+				return b, cmd
+			}
+			// Nothing under the pointer handles the mouse, so the app gets the event (e.g. to close a menu when clicking
+			// away from it)
+			return b, tea.Batch(cmd, b.appComponent.Update(msg))
+		}
 	case tea.WindowSizeMsg:
+		// Styling first means the layout accounts for the margins, borders, and padding that the styles add
+		b.applyStyles()
 		b.appComponent.Resize(msg.Width, msg.Height)
+		b.canvas = NewCanvas(msg.Width, msg.Height)
 		return b, nil
 	}
 
 	return b, b.appComponent.Update(msg)
 }
 
-func (b bubbleBathModel) View() string {
-	return b.appComponent.View()
-}
-
 func (b bubbleBathModel) GetAppComponent() InteractiveComponent {
 	return b.appComponent
 }
 
+func (b bubbleBathModel) GetTheme() Theme {
+	return b.theme
+}
+
+// GetFocusManager gets the model's FocusManager, which will be nil if focus management isn't enabled
+func (b bubbleBathModel) GetFocusManager() *FocusManager {
+	return b.focusManager
+}
+
 func RunBubbleBathProgram[T InteractiveComponent](
 	appComponent T,
 	bubbleBathOptions []BubbleBathOption,
@@ -85,3 +343,42 @@ func RunBubbleBathProgram[T InteractiveComponent](
 	castedAppComponent := castedModel.appComponent.(T)
 	return castedAppComponent, err
 }
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// styleTracker records whether the tree needs styling again before it's next rendered
+type styleTracker struct {
+	isStale bool
+}
+
+// applyStyles applies the theme & style sheet to the whole tree
+func (b bubbleBathModel) applyStyles() {
+	ApplyTheme(b.appComponent, b.theme)
+	b.styleSheet.Apply(b.appComponent)
+	b.styleTracker.isStale = false
+}
+
+// doesMsgChangeStyleStates indicates whether the message is one that's likely to change the states that styles depend
+// on: input (which moves focus & highlights), focus requests, the theme changing, or overlays opening & closing
+func doesMsgChangeStyleStates(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg, RequestFocusMsg, FocusChangedMsg, ChangeThemeMsg, PushOverlayMsg, PopOverlayMsg:
+		return true
+	}
+	return false
+}
+
+func getQuitBinding(quitSequenceSet map[string]bool) key.Binding {
+	quitSequences := make([]string, 0, len(quitSequenceSet))
+	for sequence := range quitSequenceSet {
+		quitSequences = append(quitSequences, sequence)
+	}
+	sort.Strings(quitSequences)
+
+	return key.NewBinding(
+		key.WithKeys(quitSequences...),
+		key.WithHelp(strings.Join(quitSequences, "/"), "quit"),
+	)
+}
@@ -0,0 +1,61 @@
+package bubble_bath
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stylableAppFake is an app whose style states can be changed directly, counting how often it's restyled
+type stylableAppFake struct {
+	*stylableFake
+
+	styleCount int
+}
+
+func (fake *stylableAppFake) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (fake *stylableAppFake) SetFocus(isFocused bool) tea.Cmd {
+	return nil
+}
+
+func (fake *stylableAppFake) IsFocused() bool {
+	return true
+}
+
+func (fake *stylableAppFake) SetStyle(style Style) {
+	fake.styleCount++
+	fake.stylableFake.SetStyle(style)
+}
+
+func TestProgramStylesOnChangesRatherThanOnRender(t *testing.T) {
+	styleSheet := MustNewStyleSheet(StyleRule{
+		Selector: "app:highlighted",
+		Style:    NewStyle().Bold(true),
+	})
+	app := &stylableAppFake{stylableFake: newStylableFake("app", "", nil), styleCount: 0}
+	model := NewBubbleBathModel(app, WithTheme(DarkTheme), WithStyleSheet(styleSheet))
+	model.Init()
+	if app.styleCount != 1 {
+		t.Fatalf("Expected the app to be styled once on init, but it was styled %v times", app.styleCount)
+	}
+
+	app.states = []StyleState{StyleStateHighlighted}
+	model.View()
+	model, _ = model.Update(animationTickMsg{chainID: 0, time: time.Now()})
+	model.View()
+	if app.styleCount != 1 {
+		t.Errorf("Expected rendering & animation ticks not to restyle the app, but it was styled %v times", app.styleCount)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if app.styleCount != 2 {
+		t.Errorf("Expected input to restyle the app, but it was styled %v times", app.styleCount)
+	}
+	if !app.GetStyle().ToLipgloss().GetBold() {
+		t.Errorf("Expected the restyle to pick up the app's new state, but the app isn't bold")
+	}
+}
@@ -0,0 +1,447 @@
+package progress
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+const (
+	// How wide the bar itself would like to be, when there's room
+	preferredBarWidth = 40
+
+	indeterminateFPS = 20
+
+	// How much of the bar the bouncing segment of an indeterminate bar takes up
+	indeterminateSegmentFraction = 0.25
+
+	// Used for the throughput, e.g. "1.5 kB/s"
+	quantityPrefixes = "kMGTPE"
+)
+
+type ProgressOption func(*implementation)
+
+// WithStyle sets the characters the bar is drawn with (Blocks by default)
+func WithStyle(style Style) ProgressOption {
+	return func(impl *implementation) {
+		impl.style = style
+	}
+}
+
+// WithLabel sets the text shown before the bar
+func WithLabel(label string) ProgressOption {
+	return func(impl *implementation) {
+		impl.label = label
+	}
+}
+
+// WithPercentage shows the percentage done after the bar
+func WithPercentage() ProgressOption {
+	return func(impl *implementation) {
+		impl.isShowingPercentage = true
+	}
+}
+
+// WithETA shows an estimate of how long is left after the bar, based on how fast the work has gone so far (or how long
+// it took, once it's done)
+func WithETA() ProgressOption {
+	return func(impl *implementation) {
+		impl.isShowingETA = true
+	}
+}
+
+// WithThroughput shows how many units of work are being done per second after the bar (e.g. "1.5 kB/s", for a unit of
+// "B")
+func WithThroughput(unit string) ProgressOption {
+	return func(impl *implementation) {
+		impl.isShowingThroughput = true
+		impl.throughputUnit = unit
+	}
+}
+
+type implementation struct {
+	style Style
+	label string
+
+	isShowingPercentage bool
+	isShowingETA        bool
+	isShowingThroughput bool
+	throughputUnit      string
+
+	// Only shown if the bar isn't bound to a task, since a bound bar reads the progress straight off the task
+	progress bubble_bath.TaskProgress
+
+	// Nil if the bar isn't bound to a task
+	task *bubble_bath.Task
+
+	// When the work started & finished, for bars that aren't bound to a task (which has its own timing)
+	// The finish time is zero until the progress reaches its total
+	startTime  time.Time
+	finishTime time.Time
+
+	// Only runs while the bar is indeterminate
+	animation *bubble_bath.Animation
+	frameIdx  int
+
+	theme bubble_bath.Theme
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+func New(opts ...ProgressOption) Component {
+	result := &implementation{
+		style:               Blocks,
+		label:               "",
+		isShowingPercentage: false,
+		isShowingETA:        false,
+		isShowingThroughput: false,
+		throughputUnit:      "",
+		progress:            bubble_bath.TaskProgress{},
+		task:                nil,
+		startTime:           time.Now(),
+		finishTime:          time.Time{},
+		animation:           bubble_bath.NewAnimation(indeterminateFPS),
+		frameIdx:            0,
+		theme:               bubble_bath.DarkTheme,
+		isFocused:           false,
+		width:               0,
+		height:              0,
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+	return result
+}
+
+func (impl *implementation) SetProgress(progress bubble_bath.TaskProgress) tea.Cmd {
+	impl.progress = progress
+
+	fraction, isDeterminate := progress.GetFraction()
+	if isDeterminate && fraction >= 1 {
+		if impl.finishTime.IsZero() {
+			impl.finishTime = time.Now()
+		}
+	} else {
+		impl.finishTime = time.Time{}
+	}
+
+	return impl.updateAnimation()
+}
+
+func (impl *implementation) GetProgress() bubble_bath.TaskProgress {
+	return impl.getCurrentProgress()
+}
+
+func (impl *implementation) Restart() tea.Cmd {
+	impl.task = nil
+	impl.startTime = time.Now()
+	return impl.SetProgress(bubble_bath.TaskProgress{})
+}
+
+func (impl *implementation) BindTask(task *bubble_bath.Task) tea.Cmd {
+	impl.task = task
+	impl.finishTime = time.Time{}
+	return impl.updateAnimation()
+}
+
+func (impl *implementation) GetTask() *bubble_bath.Task {
+	return impl.task
+}
+
+func (impl *implementation) SetLabel(label string) {
+	impl.label = label
+}
+
+func (impl *implementation) GetLabel() string {
+	return impl.label
+}
+
+func (impl *implementation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case bubble_bath.TaskProgressMsg:
+		if impl.task != nil && msg.Task == impl.task {
+			return impl.updateAnimation()
+		}
+	case bubble_bath.TaskDoneMsg:
+		if impl.task != nil && msg.Task == impl.task {
+			return impl.updateAnimation()
+		}
+	case bubble_bath.ThemeChangedMsg:
+		impl.SetTheme(msg.Current)
+	}
+	return nil
+}
+
+func (impl *implementation) HandleAnimationFrame(msg bubble_bath.AnimationFrameMsg) tea.Cmd {
+	if msg.IsFrameFor(impl.animation) {
+		impl.frameIdx++
+	}
+
+	// The bound task's messages only reach the bar if its container passes them along (which containers needn't do for
+	// unfocused children), whereas frames reach every animated component, so this is where it notices the task is done or
+	// has become determinate
+	return impl.updateAnimation()
+}
+
+func (impl *implementation) View() string {
+	label := impl.label
+	stats := impl.getStatsText()
+
+	barWidth := impl.width - lipgloss.Width(impl.style.LeftCap) - lipgloss.Width(impl.style.RightCap)
+	if label != "" {
+		barWidth -= lipgloss.Width(label) + 1
+	}
+	if stats != "" {
+		barWidth -= lipgloss.Width(stats) + 1
+	}
+	barWidth = bubble_bath.GetMaxInt(0, barWidth)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(impl.theme.Muted)
+
+	result := ""
+	if label != "" {
+		result += lipgloss.NewStyle().Foreground(impl.theme.Foreground).Render(label) + " "
+	}
+	result += mutedStyle.Render(impl.style.LeftCap) + impl.renderBar(barWidth) + mutedStyle.Render(impl.style.RightCap)
+	if stats != "" {
+		result += " " + mutedStyle.Render(stats)
+	}
+
+	return lipgloss.NewStyle().
+		MaxWidth(impl.width).
+		MaxHeight(impl.height).
+		Render(result)
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+}
+
+func (impl *implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl *implementation) GetHeight() int {
+	return impl.height
+}
+
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	// Everything but the bar, plus enough bar to see
+	return impl.getNonBarWidth() + 1
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return impl.getNonBarWidth() + preferredBarWidth
+}
+
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	return 1
+}
+
+func (impl *implementation) SetTheme(theme bubble_bath.Theme) {
+	impl.theme = theme
+}
+
+func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
+	impl.isFocused = isFocused
+	return nil
+}
+
+func (impl *implementation) IsFocused() bool {
+	return impl.isFocused
+}
+
+// IsFocusable keeps the focus manager from stopping on progress bars, since there's nothing to do with them
+func (impl *implementation) IsFocusable() bool {
+	return false
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// updateAnimation starts the animation if the bar has just become indeterminate, and stops it if it no longer is
+func (impl *implementation) updateAnimation() tea.Cmd {
+	_, isDeterminate := impl.getCurrentProgress().GetFraction()
+	shouldAnimate := !isDeterminate && !impl.isTaskDone()
+
+	if shouldAnimate == impl.animation.IsRunning() {
+		return nil
+	}
+	if !shouldAnimate {
+		impl.animation.Stop()
+		return nil
+	}
+	impl.frameIdx = 0
+	return impl.animation.Start()
+}
+
+// getCurrentProgress gets the bound task's latest progress, which is read straight off the task since its messages don't
+// necessarily reach the bar, or the progress that was set otherwise
+func (impl *implementation) getCurrentProgress() bubble_bath.TaskProgress {
+	if impl.task != nil {
+		return impl.task.GetProgress()
+	}
+	return impl.progress
+}
+
+func (impl *implementation) isTaskDone() bool {
+	return impl.task != nil && impl.task.IsDone()
+}
+
+func (impl *implementation) getNonBarWidth() int {
+	result := lipgloss.Width(impl.style.LeftCap) + lipgloss.Width(impl.style.RightCap)
+	if impl.label != "" {
+		result += lipgloss.Width(impl.label) + 1
+	}
+	if stats := impl.getStatsText(); stats != "" {
+		result += lipgloss.Width(stats) + 1
+	}
+	return result
+}
+
+// getStatsText gets the percentage, ETA, and throughput (whichever are shown), or how the bound task ended if it didn't
+// succeed
+func (impl *implementation) getStatsText() string {
+	if task := impl.task; task != nil && task.IsDone() {
+		switch {
+		case task.IsCancelled():
+			return "cancelled"
+		case task.GetError() != nil:
+			return "failed"
+		}
+	}
+
+	fraction, isDeterminate := impl.getFraction()
+	elapsed := impl.getElapsed()
+
+	stats := make([]string, 0, 3)
+	if impl.isShowingPercentage && isDeterminate {
+		stats = append(stats, fmt.Sprintf("%3d%%", int(fraction*100)))
+	}
+	if impl.isShowingETA && isDeterminate {
+		switch {
+		case fraction >= 1:
+			stats = append(stats, "took "+formatDuration(elapsed))
+		case fraction <= 0:
+			stats = append(stats, "ETA --")
+		default:
+			remaining := time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+			stats = append(stats, "ETA "+formatDuration(remaining))
+		}
+	}
+	if impl.isShowingThroughput {
+		perSecond := 0.0
+		if elapsed > 0 {
+			perSecond = float64(impl.getCurrentProgress().Completed) / elapsed.Seconds()
+		}
+		stats = append(stats, formatQuantity(perSecond, impl.throughputUnit)+"/s")
+	}
+	return strings.Join(stats, "  ")
+}
+
+// getFraction gets how full the bar is, which is full once the bound task has succeeded (whether or not it reported
+// its progress all the way to the end)
+func (impl *implementation) getFraction() (float64, bool) {
+	if task := impl.task; task != nil && task.IsDone() && task.GetError() == nil {
+		return 1, true
+	}
+	return impl.getCurrentProgress().GetFraction()
+}
+
+func (impl *implementation) getElapsed() time.Duration {
+	if impl.task != nil {
+		return impl.task.GetElapsed()
+	}
+	if !impl.finishTime.IsZero() {
+		return impl.finishTime.Sub(impl.startTime)
+	}
+	return time.Since(impl.startTime)
+}
+
+func (impl *implementation) renderBar(width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	filledColor := impl.theme.Accent
+	if task := impl.task; task != nil && task.IsDone() {
+		switch {
+		case task.IsCancelled():
+			filledColor = impl.theme.Muted
+		case task.GetError() != nil:
+			filledColor = impl.theme.Error
+		default:
+			filledColor = impl.theme.Success
+		}
+	}
+	filledStyle := lipgloss.NewStyle().Foreground(filledColor)
+	emptyStyle := lipgloss.NewStyle().Foreground(impl.theme.Muted)
+
+	fraction, isDeterminate := impl.getFraction()
+	if !isDeterminate {
+		if impl.isTaskDone() {
+			return emptyStyle.Render(strings.Repeat(string(impl.style.Empty), width))
+		}
+		segmentStart, segmentWidth := impl.getIndeterminateSegment(width)
+		return emptyStyle.Render(strings.Repeat(string(impl.style.Empty), segmentStart)) +
+			filledStyle.Render(strings.Repeat(string(impl.style.Filled), segmentWidth)) +
+			emptyStyle.Render(strings.Repeat(string(impl.style.Empty), width-segmentStart-segmentWidth))
+	}
+
+	filledCells := fraction * float64(width)
+	numFilled := int(math.Floor(filledCells))
+	filled := strings.Repeat(string(impl.style.Filled), numFilled)
+
+	// The cell where the done part ends gets partly filled, if the style allows it
+	numPartials := len(impl.style.Partials)
+	if numFilled < width && numPartials > 0 {
+		partialIdx := int((filledCells-float64(numFilled))*float64(numPartials+1)) - 1
+		if partialIdx >= 0 {
+			filled += string(impl.style.Partials[partialIdx])
+			numFilled++
+		}
+	}
+
+	return filledStyle.Render(filled) + emptyStyle.Render(strings.Repeat(string(impl.style.Empty), width-numFilled))
+}
+
+// getIndeterminateSegment gets where the segment of an indeterminate bar is, as it bounces from one end of the bar to
+// the other and back
+func (impl *implementation) getIndeterminateSegment(width int) (int, int) {
+	segmentWidth := bubble_bath.Clamp(int(float64(width)*indeterminateSegmentFraction), 1, width)
+	travel := width - segmentWidth
+	if travel <= 0 {
+		return 0, width
+	}
+	position := impl.frameIdx % (2 * travel)
+	if position > travel {
+		position = 2*travel - position
+	}
+	return position, segmentWidth
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Second).String()
+}
+
+// formatQuantity formats the quantity with an SI prefix (e.g. "1.5 kB")
+func formatQuantity(quantity float64, unit string) string {
+	prefix := ""
+	for _, candidate := range quantityPrefixes {
+		if quantity < 1000 {
+			break
+		}
+		quantity /= 1000
+		prefix = string(candidate)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%.1f %s%s", quantity, prefix, unit))
+}
@@ -0,0 +1,75 @@
+package progress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// The bar's container may not pass the task's messages along, so it should follow the task without them
+func TestProgressFollowsTaskWithoutTaskMessages(t *testing.T) {
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	task, waitCmd := bubble_bath.StartTask("test", func(ctx context.Context, reportProgress func(progress bubble_bath.TaskProgress)) (any, error) {
+		<-release
+		reportProgress(bubble_bath.TaskProgress{Completed: 5, Total: 10, Message: ""})
+		close(reported)
+		<-release
+		return nil, nil
+	})
+
+	bar := New(WithPercentage())
+	bar.Resize(20, 1)
+	bar.BindTask(task)
+	if !bar.(*implementation).animation.IsRunning() {
+		t.Fatal("Expected the bar to animate while its task's progress is indeterminate")
+	}
+
+	release <- struct{}{}
+	<-reported
+	bar.HandleAnimationFrame(bubble_bath.AnimationFrameMsg{Time: time.Now()})
+	expectedProgress := bubble_bath.TaskProgress{Completed: 5, Total: 10, Message: ""}
+	if bar.GetProgress() != expectedProgress {
+		t.Errorf("Expected the bar to show the task's progress %+v, but got %+v", expectedProgress, bar.GetProgress())
+	}
+	if bar.(*implementation).animation.IsRunning() {
+		t.Error("Expected the bar to stop animating once its task's progress became determinate")
+	}
+
+	close(release)
+	waitForTaskDone(waitCmd)
+	if fraction, _ := bar.(*implementation).getFraction(); fraction != 1 {
+		t.Errorf("Expected the bar to be full once its task succeeded, but it was at %v", fraction)
+	}
+}
+
+// The bar's container may not pass the task's messages along, so it should stop animating on the next frame without them
+func TestProgressStopsOnFrameOnceTaskIsDone(t *testing.T) {
+	release := make(chan struct{})
+	task, waitCmd := bubble_bath.StartTask("test", func(ctx context.Context, reportProgress func(progress bubble_bath.TaskProgress)) (any, error) {
+		<-release
+		return nil, nil
+	})
+
+	bar := New()
+	bar.BindTask(task)
+	close(release)
+	waitForTaskDone(waitCmd)
+
+	bar.HandleAnimationFrame(bubble_bath.AnimationFrameMsg{Time: time.Now()})
+	if bar.(*implementation).animation.IsRunning() {
+		t.Error("Expected the bar to stop animating on the first frame after its task was done")
+	}
+}
+
+// waitForTaskDone runs the task's command until it reports that the task is done, dropping any progress messages
+func waitForTaskDone(waitCmd tea.Cmd) {
+	for {
+		if _, ok := waitCmd().(bubble_bath.TaskDoneMsg); ok {
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package progress
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// Component is a bar showing how far along some work is, optionally followed by the percentage done, an estimate of
+// the time left, and the rate the work is going at
+// Progress without a total (see bubble_bath.TaskProgress) makes the bar indeterminate, in which case a segment bounces
+// back & forth along it until it gets a total
+// The bar can be bound to a task (see BindTask) so that it follows the task's progress, and stops once the task is done
+type Component interface {
+	bubble_bath.InteractiveComponent
+	bubble_bath.FocusabilityReportingComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.ThemedComponent
+	bubble_bath.AnimatedComponent
+
+	// SetProgress sets how far along the work is, returning the command that drives the animation if the progress is
+	// indeterminate
+	// A bar that's bound to a task shows the task's progress instead
+	SetProgress(progress bubble_bath.TaskProgress) tea.Cmd
+	GetProgress() bubble_bath.TaskProgress
+
+	// Restart clears the progress (unbinding the bar from its task, if it has one), and starts timing the work (for the
+	// ETA & throughput) from now
+	Restart() tea.Cmd
+
+	// BindTask follows the task's progress until it's done, after which the bar shows whether it succeeded, failed, or
+	// was cancelled
+	BindTask(task *bubble_bath.Task) tea.Cmd
+
+	// GetTask gets the bound task, or nil if there isn't one
+	GetTask() *bubble_bath.Task
+
+	SetLabel(label string)
+	GetLabel() string
+}
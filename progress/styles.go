@@ -0,0 +1,54 @@
+package progress
+
+// Style is the characters that a progress bar is drawn with
+type Style struct {
+	// Fills the done part of the bar
+	Filled rune
+
+	// Fills the rest of the bar
+	Empty rune
+
+	// Optionally, the partially-filled characters (from least to most filled) used for the cell where the done part of
+	// the bar ends, for finer-grained progress than whole cells
+	Partials []rune
+
+	// Optionally, drawn on either side of the bar
+	LeftCap  string
+	RightCap string
+}
+
+var (
+	Blocks = Style{
+		Filled:   '█',
+		Empty:    '░',
+		Partials: nil,
+		LeftCap:  "",
+		RightCap: "",
+	}
+
+	// Smooth fills the bar in eighths of a cell
+	Smooth = Style{
+		Filled:   '█',
+		Empty:    ' ',
+		Partials: []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉'},
+		LeftCap:  "▕",
+		RightCap: "▏",
+	}
+
+	// Line is a thin line whose done part is in the theme's accent color
+	Line = Style{
+		Filled:   '━',
+		Empty:    '━',
+		Partials: []rune{'╸'},
+		LeftCap:  "",
+		RightCap: "",
+	}
+
+	ASCII = Style{
+		Filled:   '=',
+		Empty:    ' ',
+		Partials: []rune{'-'},
+		LeftCap:  "[",
+		RightCap: "]",
+	}
+)
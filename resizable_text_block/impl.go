@@ -65,14 +65,14 @@ func (item *implementation) GetContents() string {
 
 func (item *implementation) View() string {
 	// TODO add the nice '...' for when the item is cut off
-	return item.style.Render(item.contents)
-	/*
-		return item.style.
-			MaxWidth(item.width).
-			MaxHeight(item.height).
-			Render(item.contents)
-
-	*/
+	contents := item.contents
+	if item.width > 0 {
+		contents = wordwrap.String(contents, item.width)
+	}
+	return item.style.
+		MaxWidth(item.width).
+		MaxHeight(item.height).
+		Render(contents)
 }
 
 func (item *implementation) Resize(width int, height int) {
@@ -101,5 +101,5 @@ func (item *implementation) GetHeightGivenWidth(width int) int {
 		return item.maximumIntrinsicHeight
 	}
 	wrappedStr := wordwrap.String(item.contents, width)
-	return lipgloss.Width(wrappedStr)
+	return lipgloss.Height(wrappedStr)
 }
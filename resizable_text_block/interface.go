@@ -5,7 +5,7 @@ import (
 )
 
 type Component interface {
-	bubble_bath.Component
+	bubble_bath.IntrinsicallySizedComponent
 
 	// GetContents gets the raw contents of the text block, without truncation
 	GetContents() string
@@ -0,0 +1,492 @@
+package router
+
+import (
+	"math"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+const (
+	defaultBreadcrumbSeparator = " › "
+
+	// Shown in place of the leading breadcrumbs when they don't all fit
+	breadcrumbsTruncationMarker = "…"
+
+	transitionFPS = 60
+)
+
+type RouterOption func(*implementation)
+
+// WithBreadcrumbs shows the names of the screens in the stack on a line above the active screen
+func WithBreadcrumbs() RouterOption {
+	return func(impl *implementation) {
+		impl.isShowingBreadcrumbs = true
+	}
+}
+
+// WithBreadcrumbSeparator sets what goes between the breadcrumbs
+func WithBreadcrumbSeparator(separator string) RouterOption {
+	return func(impl *implementation) {
+		impl.breadcrumbSeparator = separator
+	}
+}
+
+// WithBackBinding makes the key go back to the previous screen, no matter which of the active screen's components is
+// focused (since the router captures it before they get it; see bubble_bath.WithEventBubbling)
+func WithBackBinding(binding key.Binding) RouterOption {
+	return func(impl *implementation) {
+		impl.backBinding = binding
+		impl.hasBackBinding = true
+	}
+}
+
+// WithTransition slides each new screen in over the given duration (from the right when pushing or replacing, and
+// from the left when popping)
+func WithTransition(duration time.Duration) RouterOption {
+	return func(impl *implementation) {
+		impl.transitionDuration = duration
+	}
+}
+
+// transition is an in-progress slide from one screen to another
+type transition struct {
+	// The screen that was active before, which slides out as the new one slides in
+	outgoing bubble_bath.InteractiveComponent
+
+	// True when going back to a previous screen, which slides in from the left instead of the right
+	isBackward bool
+
+	// True when the outgoing screen was popped or replaced (rather than pushed beneath the new one), in which case it
+	// stays mounted until it's finished sliding out
+	isOutgoingRemoved bool
+
+	// How far the slide has gotten, from 0 to 1 (already eased)
+	progress float64
+}
+
+type implementation struct {
+	// From bottom to top
+	screens []Screen
+
+	isShowingBreadcrumbs bool
+	breadcrumbSeparator  string
+
+	backBinding    key.Binding
+	hasBackBinding bool
+
+	transitionDuration time.Duration
+
+	// Nil when no transition is happening
+	currentTransition *transition
+
+	transitionAnimation *bubble_bath.Animation
+
+	theme bubble_bath.Theme
+
+	// Screens only get mounted & unmounted if the router itself is mounted, with the context the router was mounted with
+	isMounted    bool
+	mountContext bubble_bath.MountContext
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+// New creates a router whose bottommost screen is the given one
+func New(rootScreen Screen, opts ...RouterOption) Component {
+	result := &implementation{
+		screens:              []Screen{rootScreen},
+		isShowingBreadcrumbs: false,
+		breadcrumbSeparator:  defaultBreadcrumbSeparator,
+		backBinding:          key.Binding{},
+		hasBackBinding:       false,
+		transitionDuration:   0,
+		currentTransition:    nil,
+		transitionAnimation:  bubble_bath.NewAnimation(transitionFPS),
+		theme:                bubble_bath.DarkTheme,
+		isMounted:            false,
+		mountContext:         bubble_bath.MountContext{KeyMapConfig: nil},
+		isFocused:            false,
+		width:                0,
+		height:               0,
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+	return result
+}
+
+func (impl *implementation) Push(screen Screen) tea.Cmd {
+	outgoing := impl.GetActiveScreen().Component
+	impl.screens = append(impl.screens, screen)
+
+	// Any transition that's still going is finished first, so that a screen that's sliding out gets unmounted before
+	// it's (possibly) mounted again
+	return tea.Batch(
+		impl.finishTransition(),
+		impl.mountIfNecessary(screen.Component),
+		impl.activateTopScreen(outgoing, false, false),
+	)
+}
+
+func (impl *implementation) Pop() tea.Cmd {
+	if len(impl.screens) <= 1 {
+		return nil
+	}
+
+	outgoing := impl.GetActiveScreen().Component
+	impl.screens = impl.screens[:len(impl.screens)-1]
+	return tea.Batch(impl.finishTransition(), impl.activateTopScreen(outgoing, true, true))
+}
+
+func (impl *implementation) Replace(screen Screen) tea.Cmd {
+	outgoing := impl.GetActiveScreen().Component
+	impl.screens[len(impl.screens)-1] = screen
+	return tea.Batch(
+		impl.finishTransition(),
+		impl.mountIfNecessary(screen.Component),
+		impl.activateTopScreen(outgoing, false, true),
+	)
+}
+
+func (impl *implementation) GetScreens() []Screen {
+	return impl.screens
+}
+
+func (impl *implementation) GetActiveScreen() Screen {
+	return impl.screens[len(impl.screens)-1]
+}
+
+func (impl *implementation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case PushMsg:
+		return impl.Push(msg.Screen)
+	case PopMsg:
+		return impl.Pop()
+	case ReplaceMsg:
+		return impl.Replace(msg.Screen)
+	case bubble_bath.ThemeChangedMsg:
+		impl.SetTheme(msg.Current)
+		return bubble_bath.BroadcastThemeChange(impl.getScreenComponents(), msg)
+	case tea.KeyMsg, tea.MouseMsg:
+		return impl.GetActiveScreen().Component.Update(msg)
+	}
+
+	cmds := make([]tea.Cmd, 0, len(impl.screens))
+	for _, screen := range impl.screens {
+		cmds = append(cmds, screen.Component.Update(msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (impl *implementation) HandleAnimationFrame(msg bubble_bath.AnimationFrameMsg) tea.Cmd {
+	if msg.IsFrameFor(impl.transitionAnimation) {
+		return impl.advanceTransition(msg.Time)
+	}
+	return nil
+}
+
+// CaptureEvent goes back to the previous screen on the back key (if the router has one), before the active screen gets
+// the chance to handle it
+func (impl *implementation) CaptureEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !impl.canGoBack() || !key.Matches(keyMsg, impl.backBinding) {
+		return nil, false
+	}
+	return impl.Pop(), true
+}
+
+// ShortHelp gets the back key while there's a screen to go back to
+func (impl *implementation) ShortHelp() []key.Binding {
+	if !impl.canGoBack() {
+		return []key.Binding{}
+	}
+	return []key.Binding{impl.backBinding}
+}
+
+func (impl *implementation) FullHelp() [][]key.Binding {
+	return [][]key.Binding{impl.ShortHelp()}
+}
+
+func (impl *implementation) View() string {
+	return bubble_bath.RenderDrawable(impl)
+}
+
+// Draw draws the breadcrumbs (if shown) and then the active screen, or both screens partway through sliding if a
+// transition is happening
+func (impl *implementation) Draw(canvas *bubble_bath.Canvas) {
+	if impl.isShowingBreadcrumbs {
+		impl.drawBreadcrumbs(canvas)
+	}
+
+	screenRectangle := impl.getScreenRectangle()
+	screenCanvas := canvas.SubCanvas(screenRectangle)
+	if impl.currentTransition == nil {
+		bubble_bath.DrawComponent(screenCanvas, impl.GetActiveScreen().Component)
+		return
+	}
+
+	// The screens get drawn on their own canvases first, since sliding them partly off the edge of a sub-canvas would
+	// clip them from the wrong side
+	incomingOffset := int(math.Round(float64(screenRectangle.Width) * (1 - impl.currentTransition.progress)))
+	outgoingOffset := incomingOffset - screenRectangle.Width
+	if impl.currentTransition.isBackward {
+		incomingOffset = -incomingOffset
+		outgoingOffset = -outgoingOffset
+	}
+	screenCanvas.DrawCanvas(outgoingOffset, 0, drawOnOwnCanvas(impl.currentTransition.outgoing, screenRectangle))
+	screenCanvas.DrawCanvas(incomingOffset, 0, drawOnOwnCanvas(impl.GetActiveScreen().Component, screenRectangle))
+}
+
+// Resize resizes only the active screen; the screens beneath get resized when they become active again
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+
+	screenRectangle := impl.getScreenRectangle()
+	impl.GetActiveScreen().Component.Resize(screenRectangle.Width, screenRectangle.Height)
+	if impl.currentTransition != nil {
+		impl.currentTransition.outgoing.Resize(screenRectangle.Width, screenRectangle.Height)
+	}
+}
+
+func (impl *implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl *implementation) GetHeight() int {
+	return impl.height
+}
+
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	return bubble_bath.GetMinimumIntrinsicWidth(impl.GetActiveScreen().Component)
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return bubble_bath.GetMaximumIntrinsicWidth(impl.GetActiveScreen().Component)
+}
+
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	return bubble_bath.GetHeightGivenWidth(impl.GetActiveScreen().Component, width) + impl.getBreadcrumbsHeight()
+}
+
+// GetChildren gets only the active screen, since the screens beneath it shouldn't be reachable
+func (impl *implementation) GetChildren() []bubble_bath.Component {
+	return []bubble_bath.Component{impl.GetActiveScreen().Component}
+}
+
+// GetHiddenChildren gets the screens beneath the active one, plus the screen that's sliding out after being popped or
+// replaced (since it's still mounted until it's gone)
+func (impl *implementation) GetHiddenChildren() []bubble_bath.Component {
+	results := impl.getScreenComponents()[:len(impl.screens)-1]
+	if impl.currentTransition != nil && impl.currentTransition.isOutgoingRemoved {
+		results = append(results, impl.currentTransition.outgoing)
+	}
+	return results
+}
+
+func (impl *implementation) Mount(ctx bubble_bath.MountContext) tea.Cmd {
+	impl.isMounted = true
+	impl.mountContext = ctx
+	return nil
+}
+
+func (impl *implementation) Unmount() tea.Cmd {
+	impl.isMounted = false
+	return nil
+}
+
+func (impl *implementation) GetChildRectangles() []bubble_bath.Rectangle {
+	return []bubble_bath.Rectangle{impl.getScreenRectangle()}
+}
+
+func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
+	impl.isFocused = isFocused
+	return impl.GetActiveScreen().Component.SetFocus(isFocused)
+}
+
+func (impl *implementation) IsFocused() bool {
+	return impl.isFocused
+}
+
+func (impl *implementation) SetTheme(theme bubble_bath.Theme) {
+	impl.theme = theme
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// activateTopScreen moves focus from the previously-active screen to the new top screen, sizes the new top screen
+// (since it may have missed resizes while it was beneath the others), and starts the transition between them
+// If the outgoing screen was removed from the stack it gets unmounted, once it's finished sliding out if there's a
+// transition
+func (impl *implementation) activateTopScreen(
+	outgoing bubble_bath.InteractiveComponent,
+	isBackward bool,
+	isOutgoingRemoved bool,
+) tea.Cmd {
+	incoming := impl.GetActiveScreen().Component
+
+	cmds := []tea.Cmd{}
+	if impl.isFocused {
+		cmds = append(cmds, outgoing.SetFocus(false))
+	}
+
+	screenRectangle := impl.getScreenRectangle()
+	incoming.Resize(screenRectangle.Width, screenRectangle.Height)
+
+	if impl.isFocused {
+		cmds = append(cmds, incoming.SetFocus(true))
+	}
+
+	if outgoing == incoming {
+		return tea.Batch(cmds...)
+	}
+	if impl.transitionDuration > 0 {
+		impl.currentTransition = &transition{
+			outgoing:          outgoing,
+			isBackward:        isBackward,
+			isOutgoingRemoved: isOutgoingRemoved,
+			progress:          0,
+		}
+		cmds = append(cmds, impl.transitionAnimation.Start())
+	} else if isOutgoingRemoved {
+		cmds = append(cmds, impl.unmountIfNecessary(outgoing))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (impl *implementation) canGoBack() bool {
+	return impl.hasBackBinding && len(impl.screens) > 1
+}
+
+func (impl *implementation) mountIfNecessary(screenComponent bubble_bath.Component) tea.Cmd {
+	if !impl.isMounted {
+		return nil
+	}
+	return bubble_bath.MountTree(screenComponent, impl.mountContext)
+}
+
+func (impl *implementation) unmountIfNecessary(screenComponent bubble_bath.Component) tea.Cmd {
+	if !impl.isMounted {
+		return nil
+	}
+	return bubble_bath.UnmountTree(screenComponent)
+}
+
+func (impl *implementation) advanceTransition(now time.Time) tea.Cmd {
+	if impl.currentTransition == nil {
+		impl.transitionAnimation.Stop()
+		return nil
+	}
+
+	elapsed := impl.transitionAnimation.GetElapsed(now)
+	if elapsed >= impl.transitionDuration {
+		return impl.finishTransition()
+	}
+	// Starts the slide fast and settles it gently into place
+	impl.currentTransition.progress = bubble_bath.GetEasedProgress(elapsed, impl.transitionDuration, bubble_bath.EaseOutCubic)
+	return nil
+}
+
+// finishTransition ends the current transition (if there is one), unmounting the outgoing screen if it was removed
+func (impl *implementation) finishTransition() tea.Cmd {
+	if impl.currentTransition == nil {
+		return nil
+	}
+
+	finished := impl.currentTransition
+	impl.currentTransition = nil
+	impl.transitionAnimation.Stop()
+	if !finished.isOutgoingRemoved {
+		return nil
+	}
+	return impl.unmountIfNecessary(finished.outgoing)
+}
+
+func (impl *implementation) getScreenComponents() []bubble_bath.Component {
+	result := make([]bubble_bath.Component, len(impl.screens))
+	for idx, screen := range impl.screens {
+		result[idx] = screen.Component
+	}
+	return result
+}
+
+func (impl *implementation) getBreadcrumbsHeight() int {
+	if impl.isShowingBreadcrumbs {
+		return 1
+	}
+	return 0
+}
+
+// getScreenRectangle gets the space left for the active screen below the breadcrumbs
+func (impl *implementation) getScreenRectangle() bubble_bath.Rectangle {
+	breadcrumbsHeight := bubble_bath.GetMinInt(impl.getBreadcrumbsHeight(), impl.height)
+	return bubble_bath.Rectangle{
+		X:      0,
+		Y:      breadcrumbsHeight,
+		Width:  impl.width,
+		Height: impl.height - breadcrumbsHeight,
+	}
+}
+
+// drawBreadcrumbs draws the screen names from bottom to top, with the active screen's highlighted
+// If they don't all fit, the bottommost ones get replaced with a truncation marker
+func (impl *implementation) drawBreadcrumbs(canvas *bubble_bath.Canvas) {
+	mutedStyle := bubble_bath.NewCellStyle(lipgloss.NewStyle().Foreground(impl.theme.Muted))
+	activeStyle := bubble_bath.NewCellStyle(lipgloss.NewStyle().Foreground(impl.theme.Accent).Bold(true))
+
+	firstShownIdx := impl.getFirstFittingBreadcrumbIdx()
+
+	x := 0
+	if firstShownIdx > 0 {
+		x += canvas.DrawString(x, 0, breadcrumbsTruncationMarker+impl.breadcrumbSeparator, mutedStyle)
+	}
+	for idx := firstShownIdx; idx < len(impl.screens); idx++ {
+		if idx > firstShownIdx {
+			x += canvas.DrawString(x, 0, impl.breadcrumbSeparator, mutedStyle)
+		}
+		style := mutedStyle
+		if idx == len(impl.screens)-1 {
+			style = activeStyle
+		}
+		x += canvas.DrawString(x, 0, impl.screens[idx].Name, style)
+	}
+}
+
+// getFirstFittingBreadcrumbIdx gets the index of the bottommost screen whose breadcrumb can be shown while still
+// showing all the ones above it
+// The active screen's breadcrumb is always shown, even if it doesn't fit (in which case the canvas clips it)
+func (impl *implementation) getFirstFittingBreadcrumbIdx() int {
+	separatorWidth := runewidth.StringWidth(impl.breadcrumbSeparator)
+	truncationMarkerWidth := runewidth.StringWidth(breadcrumbsTruncationMarker) + separatorWidth
+
+	activeIdx := len(impl.screens) - 1
+	usedWidth := runewidth.StringWidth(impl.screens[activeIdx].Name)
+	for idx := activeIdx - 1; idx >= 0; idx-- {
+		usedWidth += runewidth.StringWidth(impl.screens[idx].Name) + separatorWidth
+
+		// Unless this is the bottommost breadcrumb, there needs to be room left for the truncation marker
+		requiredWidth := usedWidth
+		if idx > 0 {
+			requiredWidth += truncationMarkerWidth
+		}
+		if requiredWidth > impl.width {
+			return idx + 1
+		}
+	}
+	return 0
+}
+
+// drawOnOwnCanvas draws the component on a fresh canvas the size of the given rectangle
+func drawOnOwnCanvas(component bubble_bath.Component, rectangle bubble_bath.Rectangle) *bubble_bath.Canvas {
+	result := bubble_bath.NewCanvas(rectangle.Width, rectangle.Height)
+	bubble_bath.DrawComponent(result, component)
+	return result
+}
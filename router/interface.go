@@ -0,0 +1,55 @@
+package router
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// Screen is a single page of a multi-page app
+type Screen struct {
+	// Shown in the breadcrumbs
+	Name string
+
+	Component bubble_bath.InteractiveComponent
+}
+
+// Component holds a stack of screens, showing only the topmost (active) one
+// Screens beneath the active one are kept as-is, so popping back to one finds it exactly as it was left
+//
+// Only the active screen gets key presses, resizes, and focus, and it's the router's only child so focus managers &
+// mouse routing can't reach the screens beneath it
+// Other messages go to every screen in the stack, so that the screens beneath keep running
+//
+// Screens (or anything else in the tree) can navigate with the Push, Pop, and Replace commands
+// Screens are mounted when they're pushed and unmounted when they're popped or replaced (see bubble_bath.Mounter), so
+// the screens beneath the active one stay mounted
+// With a transition, a popped or replaced screen is only unmounted once it's finished sliding out
+type Component interface {
+	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.LayoutContainerComponent
+	bubble_bath.HiddenChildrenContainerComponent
+	bubble_bath.Mounter
+	bubble_bath.Unmounter
+	bubble_bath.EventCapturer
+	bubble_bath.KeyBindingProvider
+	bubble_bath.DrawableComponent
+	bubble_bath.ThemedComponent
+	bubble_bath.AnimatedComponent
+
+	// Push makes the screen the active one, on top of the others
+	Push(screen Screen) tea.Cmd
+
+	// Pop removes the active screen, making the one beneath it active again
+	// The bottommost screen is never popped, so that there's always something to show
+	Pop() tea.Cmd
+
+	// Replace swaps the active screen for the given one (e.g. to go from a login screen to the app's home screen
+	// without being able to go back)
+	Replace(screen Screen) tea.Cmd
+
+	// GetScreens gets the screens, from bottom to top
+	GetScreens() []Screen
+
+	GetActiveScreen() Screen
+}
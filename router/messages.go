@@ -0,0 +1,35 @@
+package router
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// PushMsg asks the router to make the screen the active one
+type PushMsg struct {
+	Screen Screen
+}
+
+// PopMsg asks the router to go back to the screen beneath the active one
+type PopMsg struct{}
+
+// ReplaceMsg asks the router to swap the active screen for the given one
+type ReplaceMsg struct {
+	Screen Screen
+}
+
+// Push is a tea.Cmd factory that components anywhere in the tree can use to navigate to a new screen
+func Push(screen Screen) tea.Cmd {
+	return func() tea.Msg {
+		return PushMsg{Screen: screen}
+	}
+}
+
+// Pop is a tea.Cmd that goes back to the previous screen
+func Pop() tea.Msg {
+	return PopMsg{}
+}
+
+// Replace is a tea.Cmd factory that swaps the active screen for the given one
+func Replace(screen Screen) tea.Cmd {
+	return func() tea.Msg {
+		return ReplaceMsg{Screen: screen}
+	}
+}
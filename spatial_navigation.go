@@ -0,0 +1,81 @@
+package bubble_bath
+
+// findNearestRectangleInDirection finds the index of the candidate rectangle that's geometrically closest to the origin
+// rectangle in the given direction, similar to tmux's pane navigation, or -1 if there are no candidates in that direction
+//
+// Candidates that lie entirely beyond the origin's edge in the given direction are preferred, and among them the one
+// with the smallest distance wins, where distance along the perpendicular axis is penalized so that a candidate directly
+// across from the origin beats a nearer one that's off to the side
+// If no candidate lies entirely beyond the origin's edge, candidates whose center lies beyond the origin's center are
+// considered instead (which allows moving between overlapping rectangles)
+func findNearestRectangleInDirection(origin Rectangle, candidates []Rectangle, direction Direction) int {
+	const perpendicularDistancePenalty = 2
+
+	bestIdx := -1
+	bestIsBeyondEdge := false
+	bestScore := 0
+	bestCenterDistance := 0
+	for idx, candidate := range candidates {
+		if candidate.Width <= 0 || candidate.Height <= 0 {
+			continue
+		}
+
+		// Translate everything so that "forward" is always increasing along the major axis
+		originStart, originEnd, originCrossStart, originCrossEnd := getAxisExtents(origin, direction)
+		candidateStart, candidateEnd, candidateCrossStart, candidateCrossEnd := getAxisExtents(candidate, direction)
+
+		isBeyondEdge := candidateStart >= originEnd
+		isBeyondCenter := candidateStart+candidateEnd > originStart+originEnd
+		if !isBeyondEdge && !isBeyondCenter {
+			continue
+		}
+		if bestIsBeyondEdge && !isBeyondEdge {
+			continue
+		}
+
+		distance := GetMaxInt(0, candidateStart-originEnd)
+		perpendicularDistance := 0
+		if candidateCrossEnd <= originCrossStart {
+			perpendicularDistance = originCrossStart - candidateCrossEnd + 1
+		} else if candidateCrossStart >= originCrossEnd {
+			perpendicularDistance = candidateCrossStart - originCrossEnd + 1
+		}
+		score := distance + perpendicularDistancePenalty*perpendicularDistance
+
+		// Ties (e.g. several panes along the same edge) are broken by how close the candidate's center is to the
+		// origin's center along the perpendicular axis
+		centerDistance := (candidateCrossStart + candidateCrossEnd) - (originCrossStart + originCrossEnd)
+		if centerDistance < 0 {
+			centerDistance = -centerDistance
+		}
+
+		isBetter := bestIdx == -1 ||
+			(isBeyondEdge && !bestIsBeyondEdge) ||
+			score < bestScore ||
+			(score == bestScore && centerDistance < bestCenterDistance)
+		if isBetter {
+			bestIdx = idx
+			bestIsBeyondEdge = isBeyondEdge
+			bestScore = score
+			bestCenterDistance = centerDistance
+		}
+	}
+	return bestIdx
+}
+
+// getAxisExtents gets the start & end of the rectangle along the axis of the direction (oriented so that moving in the
+// direction means increasing values), and the start & end along the perpendicular axis
+func getAxisExtents(rectangle Rectangle, direction Direction) (start int, end int, crossStart int, crossEnd int) {
+	left, right := rectangle.X, rectangle.X+rectangle.Width
+	top, bottom := rectangle.Y, rectangle.Y+rectangle.Height
+	switch direction {
+	case DirectionUp:
+		return -bottom, -top, left, right
+	case DirectionDown:
+		return top, bottom, left, right
+	case DirectionLeft:
+		return -right, -left, top, bottom
+	default:
+		return left, right, top, bottom
+	}
+}
@@ -0,0 +1,284 @@
+package spinner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+const (
+	// Shown in place of the animation once a bound task is done
+	succeededSymbol = "✓"
+	failedSymbol    = "✗"
+	cancelledSymbol = "–"
+)
+
+type SpinnerOption func(*implementation)
+
+// WithStyle sets the animation (Dots by default)
+func WithStyle(style Style) SpinnerOption {
+	return func(impl *implementation) {
+		impl.style = style
+	}
+}
+
+// WithLabel sets the text shown after the animation
+func WithLabel(label string) SpinnerOption {
+	return func(impl *implementation) {
+		impl.label = label
+	}
+}
+
+// WithElapsed shows how long the spinner has been spinning for (or how long its bound task took, once it's done)
+func WithElapsed() SpinnerOption {
+	return func(impl *implementation) {
+		impl.isShowingElapsed = true
+	}
+}
+
+type implementation struct {
+	style Style
+	label string
+
+	isShowingElapsed bool
+
+	// Nil if the spinner isn't bound to a task
+	task *bubble_bath.Task
+
+	// Built from the style once the options have been applied
+	animation *bubble_bath.Animation
+	frameIdx  int
+
+	// When the spinner last started & stopped spinning, for spinners that aren't bound to a task
+	startTime time.Time
+	stopTime  time.Time
+
+	theme bubble_bath.Theme
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+func New(opts ...SpinnerOption) Component {
+	result := &implementation{
+		style:            Dots,
+		label:            "",
+		isShowingElapsed: false,
+		task:             nil,
+		animation:        nil,
+		frameIdx:         0,
+		startTime:        time.Time{},
+		stopTime:         time.Time{},
+		theme:            bubble_bath.DarkTheme,
+		isFocused:        false,
+		width:            0,
+		height:           0,
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+	result.animation = bubble_bath.NewAnimation(result.style.FPS)
+	return result
+}
+
+func (impl *implementation) Start() tea.Cmd {
+	if impl.animation.IsRunning() {
+		return nil
+	}
+	impl.frameIdx = 0
+	impl.startTime = time.Now()
+	return impl.animation.Start()
+}
+
+func (impl *implementation) Stop() {
+	if impl.animation.IsRunning() {
+		impl.stopTime = time.Now()
+	}
+	impl.animation.Stop()
+}
+
+func (impl *implementation) IsSpinning() bool {
+	return impl.animation.IsRunning()
+}
+
+func (impl *implementation) BindTask(task *bubble_bath.Task) tea.Cmd {
+	impl.Stop()
+	impl.task = task
+	if task.IsDone() {
+		return nil
+	}
+	return impl.Start()
+}
+
+func (impl *implementation) GetTask() *bubble_bath.Task {
+	return impl.task
+}
+
+func (impl *implementation) SetLabel(label string) {
+	impl.label = label
+}
+
+func (impl *implementation) GetLabel() string {
+	return impl.label
+}
+
+func (impl *implementation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case bubble_bath.TaskDoneMsg:
+		if impl.task != nil && msg.Task == impl.task {
+			impl.Stop()
+		}
+	case bubble_bath.ThemeChangedMsg:
+		impl.SetTheme(msg.Current)
+	}
+	// Progress messages need no handling, since the view reads the bound task's progress straight off it
+	return nil
+}
+
+func (impl *implementation) HandleAnimationFrame(msg bubble_bath.AnimationFrameMsg) tea.Cmd {
+	// The bound task's messages only reach the spinner if its container passes them along (which containers needn't do
+	// for unfocused children), whereas frames reach every animated component, so this is where it notices the task is done
+	if impl.task != nil && impl.task.IsDone() {
+		impl.Stop()
+		return nil
+	}
+	if msg.IsFrameFor(impl.animation) {
+		impl.frameIdx++
+	}
+	return nil
+}
+
+func (impl *implementation) View() string {
+	symbolStyle := lipgloss.NewStyle().Foreground(impl.theme.Accent)
+	textStyle := lipgloss.NewStyle().Foreground(impl.theme.Foreground)
+	mutedStyle := lipgloss.NewStyle().Foreground(impl.theme.Muted)
+
+	symbol := impl.getFrame()
+	details := impl.getDetails()
+	if task := impl.task; task != nil && task.IsDone() {
+		switch {
+		case task.IsCancelled():
+			symbol = cancelledSymbol
+			symbolStyle = mutedStyle
+			details = "cancelled"
+		case task.GetError() != nil:
+			symbol = failedSymbol
+			symbolStyle = lipgloss.NewStyle().Foreground(impl.theme.Error)
+			textStyle = symbolStyle
+			details = task.GetError().Error()
+		default:
+			symbol = succeededSymbol
+			symbolStyle = lipgloss.NewStyle().Foreground(impl.theme.Success)
+		}
+	}
+
+	text := impl.getLabelText()
+	if details != "" {
+		if text != "" {
+			text += ": "
+		}
+		text += details
+	}
+
+	result := symbolStyle.Render(symbol)
+	if text != "" {
+		result += " " + textStyle.Render(text)
+	}
+	if impl.isShowingElapsed {
+		result += " " + mutedStyle.Render(fmt.Sprintf("(%v)", impl.getElapsed().Round(time.Second)))
+	}
+
+	return lipgloss.NewStyle().
+		MaxWidth(impl.width).
+		MaxHeight(impl.height).
+		Render(result)
+}
+
+func (impl *implementation) Resize(width int, height int) {
+	impl.width = width
+	impl.height = height
+}
+
+func (impl *implementation) GetWidth() int {
+	return impl.width
+}
+
+func (impl *implementation) GetHeight() int {
+	return impl.height
+}
+
+func (impl *implementation) GetMinimumIntrinsicWidth() int {
+	// The text can be cut off, but the animation should always be visible
+	return lipgloss.Width(impl.getFrame())
+}
+
+func (impl *implementation) GetMaximumIntrinsicWidth() int {
+	return lipgloss.Width(impl.View())
+}
+
+func (impl *implementation) GetHeightGivenWidth(width int) int {
+	return 1
+}
+
+func (impl *implementation) SetTheme(theme bubble_bath.Theme) {
+	impl.theme = theme
+}
+
+func (impl *implementation) SetFocus(isFocused bool) tea.Cmd {
+	impl.isFocused = isFocused
+	return nil
+}
+
+func (impl *implementation) IsFocused() bool {
+	return impl.isFocused
+}
+
+// IsFocusable keeps the focus manager from stopping on spinners, since there's nothing to do with them
+func (impl *implementation) IsFocusable() bool {
+	return false
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (impl *implementation) getFrame() string {
+	if len(impl.style.Frames) == 0 {
+		return ""
+	}
+	return impl.style.Frames[impl.frameIdx%len(impl.style.Frames)]
+}
+
+// getLabelText gets the label, falling back to the bound task's name
+func (impl *implementation) getLabelText() string {
+	if impl.label == "" && impl.task != nil {
+		return impl.task.GetName()
+	}
+	return impl.label
+}
+
+// getDetails gets the bound task's latest progress message, if it has one
+func (impl *implementation) getDetails() string {
+	if impl.task == nil {
+		return ""
+	}
+	return strings.TrimSpace(impl.task.GetProgress().Message)
+}
+
+func (impl *implementation) getElapsed() time.Duration {
+	if impl.task != nil {
+		return impl.task.GetElapsed()
+	}
+	if impl.startTime.IsZero() {
+		return 0
+	}
+	if !impl.animation.IsRunning() {
+		return impl.stopTime.Sub(impl.startTime)
+	}
+	return time.Since(impl.startTime)
+}
@@ -0,0 +1,46 @@
+package spinner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// The spinner's container may not pass the task's messages along, so it should stop on the next frame without them
+func TestSpinnerStopsOnFrameOnceTaskIsDone(t *testing.T) {
+	release := make(chan struct{})
+	task, waitCmd := bubble_bath.StartTask("test", func(ctx context.Context, reportProgress func(progress bubble_bath.TaskProgress)) (any, error) {
+		<-release
+		return nil, nil
+	})
+
+	spinner := New()
+	spinner.BindTask(task)
+	if !spinner.IsSpinning() {
+		t.Fatal("Expected the spinner to spin while its task runs")
+	}
+
+	spinner.HandleAnimationFrame(bubble_bath.AnimationFrameMsg{Time: time.Now()})
+	if !spinner.IsSpinning() {
+		t.Fatal("Expected the spinner to keep spinning on frames while its task runs")
+	}
+
+	close(release)
+	waitForTaskDone(waitCmd)
+	spinner.HandleAnimationFrame(bubble_bath.AnimationFrameMsg{Time: time.Now()})
+	if spinner.IsSpinning() {
+		t.Error("Expected the spinner to stop on the first frame after its task was done")
+	}
+}
+
+// waitForTaskDone runs the task's command until it reports that the task is done, dropping any progress messages
+func waitForTaskDone(waitCmd tea.Cmd) {
+	for {
+		if _, ok := waitCmd().(bubble_bath.TaskDoneMsg); ok {
+			return
+		}
+	}
+}
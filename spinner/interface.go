@@ -0,0 +1,32 @@
+package spinner
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// Component is an animated indicator that something is happening, for work whose progress can't be measured
+// It can be started & stopped by hand, or bound to a task (see BindTask) so that it spins while the task runs and shows
+// how the task ended once it's done
+type Component interface {
+	bubble_bath.InteractiveComponent
+	bubble_bath.FocusabilityReportingComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.ThemedComponent
+	bubble_bath.AnimatedComponent
+
+	// Start starts the animation, returning the command that drives it
+	Start() tea.Cmd
+	Stop()
+	IsSpinning() bool
+
+	// BindTask spins until the task is done, showing the task's latest progress message (if any) after the label, and
+	// then shows whether it succeeded, failed, or was cancelled
+	BindTask(task *bubble_bath.Task) tea.Cmd
+
+	// GetTask gets the bound task, or nil if there isn't one
+	GetTask() *bubble_bath.Task
+
+	SetLabel(label string)
+	GetLabel() string
+}
@@ -0,0 +1,36 @@
+package spinner
+
+// Style is the animation that a spinner cycles through
+type Style struct {
+	Frames []string
+
+	// How many frames are shown per second
+	FPS int
+}
+
+var (
+	Line = Style{
+		Frames: []string{"|", "/", "-", "\\"},
+		FPS:    10,
+	}
+	Dots = Style{
+		Frames: []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"},
+		FPS:    10,
+	}
+	MiniDot = Style{
+		Frames: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		FPS:    12,
+	}
+	Points = Style{
+		Frames: []string{"∙∙∙", "●∙∙", "∙●∙", "∙∙●"},
+		FPS:    7,
+	}
+	Pulse = Style{
+		Frames: []string{"█", "▓", "▒", "░", "▒", "▓"},
+		FPS:    8,
+	}
+	Arc = Style{
+		Frames: []string{"◜", "◠", "◝", "◞", "◡", "◟"},
+		FPS:    10,
+	}
+)
@@ -0,0 +1,77 @@
+package bubble_bath
+
+// StyleState is a state that a component can be in, which StyleSheet selectors can match with a ":" (e.g.
+// "text_input:focused")
+type StyleState string
+
+const (
+	StyleStateFocused     StyleState = "focused"
+	StyleStateHighlighted StyleState = "highlighted"
+	StyleStateSelected    StyleState = "selected"
+)
+
+// StylableComponent is implemented by components that get their styling from a StyleSheet, the way an HTML element gets
+// its styling from CSS
+// Most of this can be gotten by embedding a StyleNode; the component only needs to supply its type & states
+type StylableComponent interface {
+	Component
+
+	// GetStyleType gets the name that type selectors match (e.g. "text_input")
+	GetStyleType() string
+
+	// HasStyleState indicates whether the component is currently in the given state
+	HasStyleState(state StyleState) bool
+
+	GetID() string
+	SetID(id string)
+
+	GetClasses() []string
+	SetClasses(classes ...string)
+
+	// GetStyle gets the style resolved for the component the last time a StyleSheet was applied
+	GetStyle() Style
+
+	// SetStyle is called by the StyleSheet with the component's resolved style
+	SetStyle(style Style)
+}
+
+// StyleNode holds the ID, classes, and resolved style of a StylableComponent, and is meant to be embedded
+type StyleNode struct {
+	id      string
+	classes []string
+	style   Style
+}
+
+func (node *StyleNode) GetID() string {
+	return node.id
+}
+
+func (node *StyleNode) SetID(id string) {
+	node.id = id
+}
+
+func (node *StyleNode) GetClasses() []string {
+	return node.classes
+}
+
+func (node *StyleNode) SetClasses(classes ...string) {
+	node.classes = classes
+}
+
+func (node *StyleNode) GetStyle() Style {
+	return node.style
+}
+
+func (node *StyleNode) SetStyle(style Style) {
+	node.style = style
+}
+
+// HasClass indicates whether the node has the given class
+func (node *StyleNode) HasClass(class string) bool {
+	for _, candidate := range node.classes {
+		if candidate == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package bubble_bath
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+type styleProperty int
+
+const (
+	foregroundProperty styleProperty = iota
+	backgroundProperty
+	boldProperty
+	italicProperty
+	underlineProperty
+	faintProperty
+	strikethroughProperty
+	reverseProperty
+	paddingTopProperty
+	paddingRightProperty
+	paddingBottomProperty
+	paddingLeftProperty
+	marginTopProperty
+	marginRightProperty
+	marginBottomProperty
+	marginLeftProperty
+	borderProperty
+	borderTopProperty
+	borderRightProperty
+	borderBottomProperty
+	borderLeftProperty
+	borderForegroundProperty
+)
+
+// Style is a set of styling properties that a StyleSheet rule sets on the components it matches
+// Unlike lipgloss.Style it keeps track of which properties were set, so that rules can be layered on top of each other
+// the way CSS rules are (e.g. a rule setting only the background doesn't clobber a less-specific rule's bold)
+// Like lipgloss.Style, the setters return a modified copy
+type Style struct {
+	properties map[styleProperty]interface{}
+}
+
+func NewStyle() Style {
+	return Style{
+		properties: map[styleProperty]interface{}{},
+	}
+}
+
+func (style Style) Foreground(color lipgloss.TerminalColor) Style {
+	return style.set(foregroundProperty, color)
+}
+
+func (style Style) Background(color lipgloss.TerminalColor) Style {
+	return style.set(backgroundProperty, color)
+}
+
+func (style Style) Bold(isBold bool) Style {
+	return style.set(boldProperty, isBold)
+}
+
+func (style Style) Italic(isItalic bool) Style {
+	return style.set(italicProperty, isItalic)
+}
+
+func (style Style) Underline(isUnderlined bool) Style {
+	return style.set(underlineProperty, isUnderlined)
+}
+
+func (style Style) Faint(isFaint bool) Style {
+	return style.set(faintProperty, isFaint)
+}
+
+func (style Style) Strikethrough(isStruckThrough bool) Style {
+	return style.set(strikethroughProperty, isStruckThrough)
+}
+
+func (style Style) Reverse(isReversed bool) Style {
+	return style.set(reverseProperty, isReversed)
+}
+
+// Padding sets the padding using the CSS shorthand: one value for all sides, two for vertical & horizontal, three for
+// top & horizontal & bottom, or four for top & right & bottom & left
+func (style Style) Padding(sizes ...int) Style {
+	top, right, bottom, left, ok := expandSideShorthand(sizes)
+	if !ok {
+		return style
+	}
+	return style.
+		set(paddingTopProperty, top).
+		set(paddingRightProperty, right).
+		set(paddingBottomProperty, bottom).
+		set(paddingLeftProperty, left)
+}
+
+// Margin sets the margin using the same shorthand as Padding
+func (style Style) Margin(sizes ...int) Style {
+	top, right, bottom, left, ok := expandSideShorthand(sizes)
+	if !ok {
+		return style
+	}
+	return style.
+		set(marginTopProperty, top).
+		set(marginRightProperty, right).
+		set(marginBottomProperty, bottom).
+		set(marginLeftProperty, left)
+}
+
+// Border sets the border, optionally only on some sides using the same shorthand as Padding (all sides by default)
+func (style Style) Border(border lipgloss.Border, sides ...bool) Style {
+	if len(sides) == 0 {
+		sides = []bool{true}
+	}
+	top, right, bottom, left, ok := expandSideShorthand(sides)
+	if !ok {
+		return style
+	}
+	return style.
+		set(borderProperty, border).
+		set(borderTopProperty, top).
+		set(borderRightProperty, right).
+		set(borderBottomProperty, bottom).
+		set(borderLeftProperty, left)
+}
+
+func (style Style) BorderForeground(color lipgloss.TerminalColor) Style {
+	return style.set(borderForegroundProperty, color)
+}
+
+// Merge gets a style with the other style's properties layered on top of this one's
+func (style Style) Merge(other Style) Style {
+	result := NewStyle()
+	for property, value := range style.properties {
+		result.properties[property] = value
+	}
+	for property, value := range other.properties {
+		result.properties[property] = value
+	}
+	return result
+}
+
+// IsEmpty indicates whether the style sets no properties at all
+func (style Style) IsEmpty() bool {
+	return len(style.properties) == 0
+}
+
+// ToLipgloss converts the style into the equivalent lipgloss.Style
+func (style Style) ToLipgloss() lipgloss.Style {
+	result := lipgloss.NewStyle()
+	for property, value := range style.properties {
+		switch property {
+		case foregroundProperty:
+			result = result.Foreground(value.(lipgloss.TerminalColor))
+		case backgroundProperty:
+			result = result.Background(value.(lipgloss.TerminalColor))
+		case boldProperty:
+			result = result.Bold(value.(bool))
+		case italicProperty:
+			result = result.Italic(value.(bool))
+		case underlineProperty:
+			result = result.Underline(value.(bool))
+		case faintProperty:
+			result = result.Faint(value.(bool))
+		case strikethroughProperty:
+			result = result.Strikethrough(value.(bool))
+		case reverseProperty:
+			result = result.Reverse(value.(bool))
+		case paddingTopProperty:
+			result = result.PaddingTop(value.(int))
+		case paddingRightProperty:
+			result = result.PaddingRight(value.(int))
+		case paddingBottomProperty:
+			result = result.PaddingBottom(value.(int))
+		case paddingLeftProperty:
+			result = result.PaddingLeft(value.(int))
+		case marginTopProperty:
+			result = result.MarginTop(value.(int))
+		case marginRightProperty:
+			result = result.MarginRight(value.(int))
+		case marginBottomProperty:
+			result = result.MarginBottom(value.(int))
+		case marginLeftProperty:
+			result = result.MarginLeft(value.(int))
+		case borderProperty:
+			result = result.BorderStyle(value.(lipgloss.Border))
+		case borderTopProperty:
+			result = result.BorderTop(value.(bool))
+		case borderRightProperty:
+			result = result.BorderRight(value.(bool))
+		case borderBottomProperty:
+			result = result.BorderBottom(value.(bool))
+		case borderLeftProperty:
+			result = result.BorderLeft(value.(bool))
+		case borderForegroundProperty:
+			result = result.BorderForeground(value.(lipgloss.TerminalColor))
+		}
+	}
+	return result
+}
+
+// GetHorizontalFrameSize gets the width taken up by the margins, borders, and padding
+func (style Style) GetHorizontalFrameSize() int {
+	return style.ToLipgloss().GetHorizontalFrameSize()
+}
+
+// GetVerticalFrameSize gets the height taken up by the margins, borders, and padding
+func (style Style) GetVerticalFrameSize() int {
+	return style.ToLipgloss().GetVerticalFrameSize()
+}
+
+// RenderWithin renders the content with the style such that the result is exactly the given size, truncating content
+// that doesn't fit inside the margins, borders, and padding (rather than wrapping it the way lipgloss would)
+func (style Style) RenderWithin(content string, width int, height int) string {
+	lipglossStyle := style.ToLipgloss()
+
+	contentWidth := GetMaxInt(0, width-lipglossStyle.GetHorizontalFrameSize())
+	contentHeight := GetMaxInt(0, height-lipglossStyle.GetVerticalFrameSize())
+	lines := strings.Split(content, "\n")
+	if len(lines) > contentHeight {
+		lines = lines[:contentHeight]
+	}
+	for idx, line := range lines {
+		lines[idx] = truncate.String(line, uint(contentWidth))
+	}
+
+	// Lipgloss' width & height include the padding, but not the borders or margins
+	return lipglossStyle.
+		Width(contentWidth + lipglossStyle.GetHorizontalPadding()).
+		Height(contentHeight + lipglossStyle.GetVerticalPadding()).
+		MaxWidth(width).
+		MaxHeight(height).
+		Render(strings.Join(lines, "\n"))
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (style Style) set(property styleProperty, value interface{}) Style {
+	result := style.Merge(NewStyle())
+	result.properties[property] = value
+	return result
+}
+
+// expandSideShorthand expands CSS' 1-4 value shorthand for the sides of a box into top, right, bottom, and left
+func expandSideShorthand[T any](values []T) (top T, right T, bottom T, left T, ok bool) {
+	switch len(values) {
+	case 1:
+		return values[0], values[0], values[0], values[0], true
+	case 2:
+		return values[0], values[1], values[0], values[1], true
+	case 3:
+		return values[0], values[1], values[2], values[1], true
+	case 4:
+		return values[0], values[1], values[2], values[3], true
+	}
+	return top, right, bottom, left, false
+}
@@ -0,0 +1,165 @@
+package bubble_bath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// specificity is CSS' selector specificity: (IDs, classes & states, types), compared in that order
+type specificity [3]int
+
+func (s specificity) isLessThan(other specificity) bool {
+	for idx := range s {
+		if s[idx] != other[idx] {
+			return s[idx] < other[idx]
+		}
+	}
+	return false
+}
+
+// compoundSelector is a selector for a single component, like "text_input#search.compact:focused"
+type compoundSelector struct {
+	// Empty (or "*") matches any type
+	styleType string
+
+	id      string
+	classes []string
+	states  []StyleState
+}
+
+// selector is a chain of compound selectors separated by the descendant combinator, like "flexbox .sidebar text_input"
+type selector struct {
+	// From outermost to innermost, where the last one is the one that must match the component being styled
+	compounds []compoundSelector
+}
+
+// parseSelectorList parses a comma-separated list of selectors
+func parseSelectorList(selectorListStr string) ([]selector, error) {
+	results := make([]selector, 0)
+	for _, selectorStr := range strings.Split(selectorListStr, ",") {
+		compoundStrs := strings.Fields(selectorStr)
+		if len(compoundStrs) == 0 {
+			return nil, fmt.Errorf("Selector list '%v' contains an empty selector", selectorListStr)
+		}
+
+		compounds := make([]compoundSelector, 0, len(compoundStrs))
+		for _, compoundStr := range compoundStrs {
+			compound, err := parseCompoundSelector(compoundStr)
+			if err != nil {
+				return nil, fmt.Errorf("An error occurred parsing selector '%v': %w", strings.TrimSpace(selectorStr), err)
+			}
+			compounds = append(compounds, compound)
+		}
+		results = append(results, selector{compounds: compounds})
+	}
+	return results, nil
+}
+
+func parseCompoundSelector(compoundStr string) (compoundSelector, error) {
+	result := compoundSelector{
+		styleType: "",
+		id:        "",
+		classes:   make([]string, 0),
+		states:    make([]StyleState, 0),
+	}
+
+	// Each part is a prefix (none for the type) followed by a name, running until the next prefix
+	remaining := compoundStr
+	isFirstPart := true
+	for len(remaining) > 0 {
+		prefix := remaining[0]
+		if prefix == '#' || prefix == '.' || prefix == ':' {
+			remaining = remaining[1:]
+		} else if isFirstPart {
+			prefix = 0
+		} else {
+			return compoundSelector{}, fmt.Errorf("Unexpected character '%c' in '%v'", prefix, compoundStr)
+		}
+		isFirstPart = false
+
+		nameEnd := strings.IndexAny(remaining, "#.:")
+		if nameEnd == -1 {
+			nameEnd = len(remaining)
+		}
+		name := remaining[:nameEnd]
+		remaining = remaining[nameEnd:]
+		if name == "" {
+			return compoundSelector{}, fmt.Errorf("Empty name in '%v'", compoundStr)
+		}
+
+		switch prefix {
+		case 0:
+			result.styleType = name
+		case '#':
+			if result.id != "" {
+				return compoundSelector{}, fmt.Errorf("Multiple IDs in '%v'", compoundStr)
+			}
+			result.id = name
+		case '.':
+			result.classes = append(result.classes, name)
+		case ':':
+			result.states = append(result.states, StyleState(name))
+		}
+	}
+	return result, nil
+}
+
+func (s selector) getSpecificity() specificity {
+	result := specificity{}
+	for _, compound := range s.compounds {
+		if compound.id != "" {
+			result[0]++
+		}
+		result[1] += len(compound.classes) + len(compound.states)
+		if compound.styleType != "" && compound.styleType != "*" {
+			result[2]++
+		}
+	}
+	return result
+}
+
+// matches checks whether the selector matches the component, given the component's stylable ancestors (outermost first)
+func (s selector) matches(component StylableComponent, ancestors []StylableComponent) bool {
+	lastCompoundIdx := len(s.compounds) - 1
+	if !s.compounds[lastCompoundIdx].matches(component) {
+		return false
+	}
+
+	// Match the remaining compounds against the ancestors, innermost first, taking the nearest matching ancestor each time
+	compoundIdx := lastCompoundIdx - 1
+	for ancestorIdx := len(ancestors) - 1; ancestorIdx >= 0 && compoundIdx >= 0; ancestorIdx-- {
+		if s.compounds[compoundIdx].matches(ancestors[ancestorIdx]) {
+			compoundIdx--
+		}
+	}
+	return compoundIdx < 0
+}
+
+func (compound compoundSelector) matches(component StylableComponent) bool {
+	if compound.styleType != "" && compound.styleType != "*" && compound.styleType != component.GetStyleType() {
+		return false
+	}
+	if compound.id != "" && compound.id != component.GetID() {
+		return false
+	}
+	for _, class := range compound.classes {
+		if !containsString(component.GetClasses(), class) {
+			return false
+		}
+	}
+	for _, state := range compound.states {
+		if !component.HasStyleState(state) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(strs []string, target string) bool {
+	for _, str := range strs {
+		if str == target {
+			return true
+		}
+	}
+	return false
+}
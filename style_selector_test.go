@@ -0,0 +1,235 @@
+package bubble_bath
+
+import (
+	"reflect"
+	"testing"
+)
+
+// stylableFake is a stylable component with a fixed type & states
+type stylableFake struct {
+	StyleNode
+
+	styleType string
+	states    []StyleState
+}
+
+func newStylableFake(styleType string, id string, classes []string, states ...StyleState) *stylableFake {
+	result := &stylableFake{
+		StyleNode: StyleNode{},
+		styleType: styleType,
+		states:    states,
+	}
+	result.SetID(id)
+	result.SetClasses(classes...)
+	return result
+}
+
+func (fake *stylableFake) View() string {
+	return ""
+}
+
+func (fake *stylableFake) Resize(width int, height int) {}
+
+func (fake *stylableFake) GetWidth() int {
+	return 0
+}
+
+func (fake *stylableFake) GetHeight() int {
+	return 0
+}
+
+func (fake *stylableFake) GetStyleType() string {
+	return fake.styleType
+}
+
+func (fake *stylableFake) HasStyleState(state StyleState) bool {
+	for _, candidate := range fake.states {
+		if candidate == state {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseSelectorList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []selector
+	}{
+		{
+			name:  "type",
+			input: "text_input",
+			expected: []selector{
+				{compounds: []compoundSelector{
+					{styleType: "text_input", id: "", classes: []string{}, states: []StyleState{}},
+				}},
+			},
+		},
+		{
+			name:  "compound",
+			input: "text_input#search.compact.dim:focused",
+			expected: []selector{
+				{compounds: []compoundSelector{
+					{styleType: "text_input", id: "search", classes: []string{"compact", "dim"}, states: []StyleState{StyleStateFocused}},
+				}},
+			},
+		},
+		{
+			name:  "no type",
+			input: ".sidebar:selected",
+			expected: []selector{
+				{compounds: []compoundSelector{
+					{styleType: "", id: "", classes: []string{"sidebar"}, states: []StyleState{StyleStateSelected}},
+				}},
+			},
+		},
+		{
+			name:  "descendants",
+			input: "flexbox  .sidebar *",
+			expected: []selector{
+				{compounds: []compoundSelector{
+					{styleType: "flexbox", id: "", classes: []string{}, states: []StyleState{}},
+					{styleType: "", id: "", classes: []string{"sidebar"}, states: []StyleState{}},
+					{styleType: "*", id: "", classes: []string{}, states: []StyleState{}},
+				}},
+			},
+		},
+		{
+			name:  "list",
+			input: "box, #title",
+			expected: []selector{
+				{compounds: []compoundSelector{
+					{styleType: "box", id: "", classes: []string{}, states: []StyleState{}},
+				}},
+				{compounds: []compoundSelector{
+					{styleType: "", id: "title", classes: []string{}, states: []StyleState{}},
+				}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := parseSelectorList(testCase.input)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected %+v but got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseSelectorListErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "empty selector in list", input: "box,,text_input"},
+		{name: "trailing comma", input: "box,"},
+		{name: "empty class", input: "box."},
+		{name: "empty state", input: "box:"},
+		{name: "doubled prefix", input: "box..compact"},
+		{name: "multiple IDs", input: "#a#b"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := parseSelectorList(testCase.input); err == nil {
+				t.Errorf("Expected an error parsing '%v' but got none", testCase.input)
+			}
+		})
+	}
+}
+
+func TestSelectorSpecificity(t *testing.T) {
+	testCases := []struct {
+		selector string
+		expected specificity
+	}{
+		{selector: "*", expected: specificity{0, 0, 0}},
+		{selector: "text_input", expected: specificity{0, 0, 1}},
+		{selector: ".compact", expected: specificity{0, 1, 0}},
+		{selector: ":focused", expected: specificity{0, 1, 0}},
+		{selector: "#search", expected: specificity{1, 0, 0}},
+		{selector: "text_input#search.compact:focused", expected: specificity{1, 2, 1}},
+		{selector: "flexbox .sidebar text_input", expected: specificity{0, 1, 2}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.selector, func(t *testing.T) {
+			selectors, err := parseSelectorList(testCase.selector)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			actual := selectors[0].getSpecificity()
+			if actual != testCase.expected {
+				t.Errorf("Expected %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSpecificityIsLessThan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        specificity
+		b        specificity
+		expected bool
+	}{
+		{name: "equal", a: specificity{1, 1, 1}, b: specificity{1, 1, 1}, expected: false},
+		{name: "fewer types", a: specificity{0, 0, 1}, b: specificity{0, 0, 2}, expected: true},
+		{name: "classes beat any number of types", a: specificity{0, 0, 9}, b: specificity{0, 1, 0}, expected: true},
+		{name: "IDs beat any number of classes", a: specificity{0, 9, 9}, b: specificity{1, 0, 0}, expected: true},
+		{name: "greater", a: specificity{1, 0, 0}, b: specificity{0, 9, 9}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := testCase.a.isLessThan(testCase.b)
+			if actual != testCase.expected {
+				t.Errorf("Expected %v < %v to be %v", testCase.a, testCase.b, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	root := newStylableFake("flexbox", "root", nil)
+	sidebar := newStylableFake("box", "", []string{"sidebar", "compact"})
+	input := newStylableFake("text_input", "search", []string{"dim"}, StyleStateFocused)
+	ancestors := []StylableComponent{root, sidebar}
+
+	testCases := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "*", expected: true},
+		{selector: "text_input", expected: true},
+		{selector: "box", expected: false},
+		{selector: "#search.dim:focused", expected: true},
+		{selector: "#other", expected: false},
+		{selector: ".dim.compact", expected: false},
+		{selector: ":selected", expected: false},
+		{selector: ".sidebar text_input", expected: true},
+		{selector: "#root .compact :focused", expected: true},
+		{selector: "#root #root text_input", expected: false},
+		{selector: ".sidebar #root text_input", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.selector, func(t *testing.T) {
+			selectors, err := parseSelectorList(testCase.selector)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			actual := selectors[0].matches(input, ancestors)
+			if actual != testCase.expected {
+				t.Errorf("Expected match to be %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package bubble_bath
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NewDefaultStyleSheet creates the style sheet that apps get unless they set their own with WithStyleSheet or
+// WithThemedStyleSheet, with its colors taken from the theme
+func NewDefaultStyleSheet(theme Theme) *StyleSheet {
+	return MustNewStyleSheet(
+		StyleRule{
+			Selector: "filterable_list_item:highlighted",
+			Style:    NewStyle().Background(theme.Selection).Bold(true),
+		},
+		StyleRule{
+			Selector: "command_palette_item:highlighted",
+			Style:    NewStyle().Background(theme.Selection),
+		},
+	)
+}
+
+// StyleRule applies the style to every StylableComponent matching the selector
+//
+// Selectors follow CSS: a type ("text_input"), ID ("#search"), classes (".compact"), and states (":focused") can be
+// combined into a compound selector ("text_input#search:focused"), compound selectors separated by spaces match
+// descendants ("flexbox .sidebar text_input"), and selectors separated by commas are alternatives
+type StyleRule struct {
+	Selector string
+	Style    Style
+}
+
+// parsedStyleRule is a StyleRule whose selector list has been parsed
+type parsedStyleRule struct {
+	selectors []selector
+	style     Style
+}
+
+// StyleSheet resolves the style of every StylableComponent in a component tree from a list of rules, the way CSS does
+// When several rules match a component their styles are layered in order of increasing specificity, with later rules
+// winning ties, so each property comes from the most specific rule that sets it
+type StyleSheet struct {
+	rules []parsedStyleRule
+}
+
+// NewStyleSheet creates a style sheet from the rules, returning an error if any of the selectors are invalid
+func NewStyleSheet(rules ...StyleRule) (*StyleSheet, error) {
+	parsedRules := make([]parsedStyleRule, 0, len(rules))
+	for _, rule := range rules {
+		selectors, err := parseSelectorList(rule.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("An error occurred parsing the selector for style rule '%v': %w", rule.Selector, err)
+		}
+		parsedRules = append(parsedRules, parsedStyleRule{
+			selectors: selectors,
+			style:     rule.Style,
+		})
+	}
+	return &StyleSheet{
+		rules: parsedRules,
+	}, nil
+}
+
+// MustNewStyleSheet is NewStyleSheet, but panics if any of the selectors are invalid
+func MustNewStyleSheet(rules ...StyleRule) *StyleSheet {
+	result, err := NewStyleSheet(rules...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Extend gets a new style sheet with the given rules after this sheet's rules (so they win specificity ties)
+func (sheet *StyleSheet) Extend(rules ...StyleRule) (*StyleSheet, error) {
+	extension, err := NewStyleSheet(rules...)
+	if err != nil {
+		return nil, err
+	}
+
+	combinedRules := make([]parsedStyleRule, 0, len(sheet.rules)+len(extension.rules))
+	combinedRules = append(combinedRules, sheet.rules...)
+	combinedRules = append(combinedRules, extension.rules...)
+	return &StyleSheet{
+		rules: combinedRules,
+	}, nil
+}
+
+// MustExtend is Extend, but panics if any of the selectors are invalid
+func (sheet *StyleSheet) MustExtend(rules ...StyleRule) *StyleSheet {
+	result, err := sheet.Extend(rules...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Apply resolves the style of every StylableComponent in the tree (hidden children included; see
+// HiddenChildrenContainerComponent) and sets it on the component
+// This needs to happen whenever the tree's states change (e.g. focus moving), so the framework does it whenever
+// components are mounted, focus or the theme changes, or the user gives input
+func (sheet *StyleSheet) Apply(root Component) {
+	sheet.applyToSubtree(root, []StylableComponent{})
+}
+
+// Resolve gets the style for the component given its stylable ancestors (outermost first)
+func (sheet *StyleSheet) Resolve(component StylableComponent, ancestors []StylableComponent) Style {
+	type match struct {
+		specificity specificity
+		ruleIdx     int
+	}
+
+	matches := make([]match, 0)
+	for ruleIdx, rule := range sheet.rules {
+		// Like CSS, a rule with several selectors counts with the specificity of the most specific one that matches
+		isMatch := false
+		bestSpecificity := specificity{}
+		for _, selector := range rule.selectors {
+			if !selector.matches(component, ancestors) {
+				continue
+			}
+			if !isMatch || bestSpecificity.isLessThan(selector.getSpecificity()) {
+				bestSpecificity = selector.getSpecificity()
+			}
+			isMatch = true
+		}
+		if isMatch {
+			matches = append(matches, match{specificity: bestSpecificity, ruleIdx: ruleIdx})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].specificity.isLessThan(matches[j].specificity)
+	})
+
+	result := NewStyle()
+	for _, match := range matches {
+		result = result.Merge(sheet.rules[match.ruleIdx].style)
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (sheet *StyleSheet) applyToSubtree(component Component, ancestors []StylableComponent) {
+	if stylable, ok := component.(StylableComponent); ok {
+		stylable.SetStyle(sheet.Resolve(stylable, ancestors))
+
+		childAncestors := make([]StylableComponent, len(ancestors)+1)
+		copy(childAncestors, ancestors)
+		childAncestors[len(ancestors)] = stylable
+		ancestors = childAncestors
+	}
+
+	for _, child := range getAllChildren(component) {
+		sheet.applyToSubtree(child, ancestors)
+	}
+}
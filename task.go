@@ -0,0 +1,242 @@
+package bubble_bath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TaskProgress is how far along a task is
+type TaskProgress struct {
+	// How many units of work (e.g. bytes or files) are done
+	Completed int64
+
+	// How many units of work there are in total, or 0 if the task can't tell (i.e. its progress is indeterminate)
+	Total int64
+
+	// Optionally, what the task is doing right now (e.g. the file being loaded)
+	Message string
+}
+
+// GetFraction gets how far along the task is, from 0 to 1, or false if its progress is indeterminate
+func (progress TaskProgress) GetFraction() (float64, bool) {
+	if progress.Total <= 0 {
+		return 0, false
+	}
+	return math.Max(0, math.Min(1, float64(progress.Completed)/float64(progress.Total))), true
+}
+
+// TaskFunc is the work that a task does in the background
+// It should return as soon as possible once the context is cancelled, and can report its progress as often as it
+// likes since reports are coalesced (components only ever see the latest)
+type TaskFunc func(ctx context.Context, reportProgress func(progress TaskProgress)) (any, error)
+
+// TaskProgressMsg is sent whenever a task has reported progress since the last TaskProgressMsg
+type TaskProgressMsg struct {
+	Task     *Task
+	Progress TaskProgress
+}
+
+// TaskDoneMsg is sent when a task finishes, whether it succeeded, failed, or was cancelled
+type TaskDoneMsg struct {
+	Task *Task
+
+	// Only set if the task succeeded
+	Result any
+
+	// Set if the task failed (including by panicking), or to context.Canceled if it was cancelled
+	Err error
+}
+
+// Task is a function running in its own goroutine, which streams its progress back through the program
+// The task's messages are sent to the app component like any other message, so components should check that a
+// TaskProgressMsg or TaskDoneMsg is for one of their own tasks before acting on it
+type Task struct {
+	id   int
+	name string
+
+	startTime time.Time
+
+	cancel context.CancelFunc
+
+	// Signalled (without blocking) each time progress is reported, so that bursts of reports only produce one message
+	progressSignal chan struct{}
+
+	// Closed once the task finishes
+	done chan struct{}
+
+	// Guards everything below, which the task's goroutine writes
+	mutex    sync.Mutex
+	progress TaskProgress
+	result   any
+	err      error
+	isDone   bool
+	endTime  time.Time
+}
+
+var (
+	nextTaskID      = 0
+	nextTaskIDMutex sync.Mutex
+)
+
+// StartTask runs the function in the background, returning the task and the command that listens for its messages
+// (which must be returned to the program for any of the task's messages to be sent)
+func StartTask(name string, fn TaskFunc) (*Task, tea.Cmd) {
+	nextTaskIDMutex.Lock()
+	id := nextTaskID
+	nextTaskID++
+	nextTaskIDMutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &Task{
+		id:             id,
+		name:           name,
+		startTime:      time.Now(),
+		cancel:         cancel,
+		progressSignal: make(chan struct{}, 1),
+		done:           make(chan struct{}),
+		mutex:          sync.Mutex{},
+		progress:       TaskProgress{},
+		result:         nil,
+		err:            nil,
+		isDone:         false,
+		endTime:        time.Time{},
+	}
+	go task.run(ctx, fn)
+	return task, task.waitForEvent()
+}
+
+func (task *Task) GetID() int {
+	return task.id
+}
+
+func (task *Task) GetName() string {
+	return task.name
+}
+
+func (task *Task) GetStartTime() time.Time {
+	return task.startTime
+}
+
+// GetElapsed gets how long the task has been running for, or how long it ran for if it's done
+func (task *Task) GetElapsed() time.Duration {
+	task.mutex.Lock()
+	defer task.mutex.Unlock()
+	if task.isDone {
+		return task.endTime.Sub(task.startTime)
+	}
+	return time.Since(task.startTime)
+}
+
+// GetProgress gets the latest progress the task has reported
+func (task *Task) GetProgress() TaskProgress {
+	task.mutex.Lock()
+	defer task.mutex.Unlock()
+	return task.progress
+}
+
+func (task *Task) IsDone() bool {
+	task.mutex.Lock()
+	defer task.mutex.Unlock()
+	return task.isDone
+}
+
+// GetResult gets what the task returned, which is only set once it's done (and only if it succeeded)
+func (task *Task) GetResult() any {
+	task.mutex.Lock()
+	defer task.mutex.Unlock()
+	return task.result
+}
+
+// GetError gets the error the task failed with, which is only set once it's done (and only if it failed)
+func (task *Task) GetError() error {
+	task.mutex.Lock()
+	defer task.mutex.Unlock()
+	return task.err
+}
+
+// IsCancelled indicates whether the task finished because it was cancelled
+func (task *Task) IsCancelled() bool {
+	return errors.Is(task.GetError(), context.Canceled)
+}
+
+// Cancel cancels the task's context, which is a no-op if the task is already done
+// The task is only done once its function returns, which it should do soon after
+func (task *Task) Cancel() {
+	task.cancel()
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func (task *Task) run(ctx context.Context, fn TaskFunc) {
+	result, err := task.callWithPanicRecovery(ctx, fn)
+
+	// Whatever the function said, a task that was cancelled didn't finish its work
+	if ctxErr := ctx.Err(); ctxErr != nil && (err == nil || errors.Is(err, ctxErr)) {
+		result = nil
+		err = context.Canceled
+	}
+
+	task.mutex.Lock()
+	if err != nil {
+		result = nil
+	}
+	task.result = result
+	task.err = err
+	task.isDone = true
+	task.endTime = time.Now()
+	task.mutex.Unlock()
+
+	task.cancel()
+	close(task.done)
+}
+
+// callWithPanicRecovery turns a panicking function into a failed task, rather than taking down the whole program
+func (task *Task) callWithPanicRecovery(ctx context.Context, fn TaskFunc) (result any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = nil
+			err = fmt.Errorf("An error occurred running task '%v': it panicked with: %v", task.name, recovered)
+		}
+	}()
+	return fn(ctx, task.reportProgress)
+}
+
+func (task *Task) reportProgress(progress TaskProgress) {
+	task.mutex.Lock()
+	task.progress = progress
+	task.mutex.Unlock()
+
+	select {
+	case task.progressSignal <- struct{}{}:
+	default:
+		// A signal is already waiting, and the message it produces will pick up this progress
+	}
+}
+
+// waitForEvent waits for the task to report progress or finish
+// The program calls this again after delivering each TaskProgressMsg, so that the task's messages arrive in order
+func (task *Task) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-task.progressSignal:
+			return TaskProgressMsg{
+				Task:     task,
+				Progress: task.GetProgress(),
+			}
+		case <-task.done:
+			return TaskDoneMsg{
+				Task:   task,
+				Result: task.GetResult(),
+				Err:    task.GetError(),
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package bubble_bath
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TaskKeyMap is the key bindings that a TaskRunner uses to control its tasks
+type TaskKeyMap struct {
+	Cancel key.Binding
+}
+
+// DefaultTaskKeyMap cancels running tasks with Esc
+var DefaultTaskKeyMap = TaskKeyMap{
+	Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+}
+
+func (keyMap TaskKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keyMap.Cancel}
+}
+
+func (keyMap TaskKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{keyMap.ShortHelp()}
+}
+
+// TaskKeyMapType is the name that key map config files use for TaskRunners' bindings
+const TaskKeyMapType = "task"
+
+func (keyMap *TaskKeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"cancel": &keyMap.Cancel,
+	}
+}
+
+func init() {
+	RegisterKeyMapType(TaskKeyMapType, &DefaultTaskKeyMap)
+}
+
+// TaskRunner keeps track of the tasks that a component has started, so that the component can cancel them all when
+// it's unmounted (see Unmounter) or when the user presses the cancel key
+type TaskRunner struct {
+	KeyMap TaskKeyMap
+
+	// Tasks that were running as of the last time they were checked, which may have finished since
+	tasks []*Task
+}
+
+func NewTaskRunner(keyMap TaskKeyMap) *TaskRunner {
+	return &TaskRunner{
+		KeyMap: keyMap,
+		tasks:  make([]*Task, 0),
+	}
+}
+
+// Start starts a task (see StartTask), returning the task and the command that must be returned to the program for the
+// task's messages to be sent
+func (runner *TaskRunner) Start(name string, fn TaskFunc) (*Task, tea.Cmd) {
+	task, cmd := StartTask(name, fn)
+	runner.tasks = append(runner.GetRunningTasks(), task)
+	return task, cmd
+}
+
+// GetRunningTasks gets the tasks that haven't finished yet, in the order they were started
+func (runner *TaskRunner) GetRunningTasks() []*Task {
+	running := make([]*Task, 0, len(runner.tasks))
+	for _, task := range runner.tasks {
+		if !task.IsDone() {
+			running = append(running, task)
+		}
+	}
+	runner.tasks = running
+	return running
+}
+
+// CancelAll cancels every running task, each of which will send a TaskDoneMsg once it's stopped
+func (runner *TaskRunner) CancelAll() {
+	for _, task := range runner.GetRunningTasks() {
+		task.Cancel()
+	}
+}
+
+// HandleKey cancels every running task if the key is the cancel key, returning true if it did
+// The key isn't handled if there's nothing to cancel, so that it can do something else (e.g. closing a dialog)
+func (runner *TaskRunner) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !key.Matches(msg, runner.KeyMap.Cancel) || len(runner.GetRunningTasks()) == 0 {
+		return nil, false
+	}
+	runner.CancelAll()
+	return nil, true
+}
+
+// ShortHelp gets the cancel key while there's something to cancel
+func (runner *TaskRunner) ShortHelp() []key.Binding {
+	if len(runner.GetRunningTasks()) == 0 {
+		return []key.Binding{}
+	}
+	return runner.KeyMap.ShortHelp()
+}
+
+func (runner *TaskRunner) FullHelp() [][]key.Binding {
+	return [][]key.Binding{runner.ShortHelp()}
+}
@@ -0,0 +1,68 @@
+package bubble_bath_testing
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+func TestBusDeliversToSubscribersInOrder(t *testing.T) {
+	topic := bubble_bath.NewTopic[string]("greetings")
+	bus := bubble_bath.NewBus()
+
+	received := []string{}
+	firstSubscription := bubble_bath.Subscribe(bus, topic, func(event string) tea.Cmd {
+		received = append(received, "first "+event)
+		return nil
+	})
+	bubble_bath.Subscribe(bus, topic, func(event string) tea.Cmd {
+		received = append(received, "second "+event)
+		return func() tea.Msg {
+			return incrementMsg{}
+		}
+	})
+
+	app := &counter{}
+	driver := New(app, 10, 1, WithBubbleBathOptions(bubble_bath.WithBus(bus)))
+
+	driver.Send(bubble_bath.Publish(topic, "hi")())
+	expectedReceived := []string{"first hi", "second hi"}
+	if !reflect.DeepEqual(received, expectedReceived) {
+		t.Errorf("Expected the subscribers to receive %v but got %v", expectedReceived, received)
+	}
+	if app.numIncrements != 1 {
+		t.Errorf("Expected the subscriber's command to reach the app once but got %v increments", app.numIncrements)
+	}
+
+	// An unsubscribed subscriber doesn't hear about later events
+	firstSubscription.Unsubscribe()
+	received = []string{}
+	driver.Send(bubble_bath.Publish(topic, "again")())
+	expectedReceived = []string{"second again"}
+	if !reflect.DeepEqual(received, expectedReceived) {
+		t.Errorf("Expected the subscribers to receive %v but got %v", expectedReceived, received)
+	}
+	if app.numIncrements != 2 {
+		t.Errorf("Expected the subscriber's command to reach the app twice but got %v increments", app.numIncrements)
+	}
+}
+
+func TestBusIgnoresOtherTopics(t *testing.T) {
+	greetings := bubble_bath.NewTopic[string]("greetings")
+	counts := bubble_bath.NewTopic[int]("counts")
+	bus := bubble_bath.NewBus()
+
+	numGreetings := 0
+	bubble_bath.Subscribe(bus, greetings, func(event string) tea.Cmd {
+		numGreetings++
+		return nil
+	})
+
+	driver := New(&counter{}, 10, 1, WithBubbleBathOptions(bubble_bath.WithBus(bus)))
+	driver.Send(bubble_bath.Publish(counts, 3)())
+	if numGreetings != 0 {
+		t.Errorf("Expected a subscriber to hear nothing about another topic but it got %v events", numGreetings)
+	}
+}
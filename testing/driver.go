@@ -0,0 +1,228 @@
+package bubble_bath_testing
+
+import (
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mieubrisse/bubble-bath"
+	"github.com/muesli/termenv"
+)
+
+const (
+	defaultCmdTimeout = 10 * time.Millisecond
+
+	// Guards against components that endlessly schedule themselves (e.g. an animation whose ticks are fast enough to
+	// beat the timeout)
+	defaultMaxMessagesPerSend = 1000
+)
+
+type DriverOption func(*Driver)
+
+// WithBubbleBathOptions mounts the component using the given options (e.g. WithFocusManagement), exactly as
+// RunBubbleBathProgram would
+func WithBubbleBathOptions(options ...bubble_bath.BubbleBathOption) DriverOption {
+	return func(driver *Driver) {
+		driver.bubbleBathOptions = options
+	}
+}
+
+// WithCmdTimeout sets how long the driver waits for each tea.Cmd to produce its message before dropping it
+// The default is short, so that commands that are meant to take a while (e.g. the animation ticker's tea.Tick) get dropped
+// rather than slowing the test down; raise it for commands that do real work (e.g. I/O)
+func WithCmdTimeout(timeout time.Duration) DriverOption {
+	return func(driver *Driver) {
+		driver.cmdTimeout = timeout
+	}
+}
+
+// WithMaxMessagesPerSend caps the number of messages that each sent message can set off (including itself), after which
+// the remaining commands are dropped
+func WithMaxMessagesPerSend(maxMessages int) DriverOption {
+	return func(driver *Driver) {
+		driver.maxMessagesPerSend = maxMessages
+	}
+}
+
+// WithColorProfile sets the color profile that lipgloss renders with, which otherwise is detected from the test's
+// (usually non-terminal) output and so produces no ANSI color codes at all
+// NOTE: this changes lipgloss' global renderer, so it affects everything else in the test binary too
+func WithColorProfile(profile termenv.Profile) DriverOption {
+	return func(driver *Driver) {
+		lipgloss.SetColorProfile(profile)
+	}
+}
+
+// Driver runs an InteractiveComponent headlessly, the way a BubbleTea program would, so that it can be tested
+// Commands are run synchronously and their messages fed back into the component before Send returns, so the component
+// is always in a settled state between calls
+type Driver struct {
+	bubbleBathOptions  []bubble_bath.BubbleBathOption
+	cmdTimeout         time.Duration
+	maxMessagesPerSend int
+
+	component bubble_bath.InteractiveComponent
+	model     tea.Model
+
+	// Every message that's been given to the model (whether sent directly or produced by a command), in order
+	processedMessages []tea.Msg
+
+	hasQuit bool
+}
+
+// New mounts the component at the given size, running its Init command
+func New(component bubble_bath.InteractiveComponent, width int, height int, opts ...DriverOption) *Driver {
+	driver := &Driver{
+		bubbleBathOptions:  nil,
+		cmdTimeout:         defaultCmdTimeout,
+		maxMessagesPerSend: defaultMaxMessagesPerSend,
+		component:          component,
+		model:              nil,
+		processedMessages:  make([]tea.Msg, 0),
+		hasQuit:            false,
+	}
+	for _, opt := range opts {
+		opt(driver)
+	}
+
+	driver.model = bubble_bath.NewBubbleBathModel(component, driver.bubbleBathOptions...)
+
+	// Real programs learn their size right as they start up
+	driver.runUntilSettled([]tea.Cmd{driver.model.Init()}, []tea.Msg{tea.WindowSizeMsg{Width: width, Height: height}})
+	return driver
+}
+
+// Send feeds the messages to the component in order, running the commands that each one produces to completion before
+// moving on to the next
+func (driver *Driver) Send(msgs ...tea.Msg) {
+	for _, msg := range msgs {
+		driver.runUntilSettled(nil, []tea.Msg{msg})
+	}
+}
+
+// Type sends the text one character at a time, as if it were typed
+func (driver *Driver) Type(text string) {
+	msgs := make([]tea.Msg, 0, len(text))
+	for _, r := range text {
+		msgs = append(msgs, runeToKeyMsg(r))
+	}
+	driver.Send(msgs...)
+}
+
+// PressKeys sends a key press for each of the given keys, written the way that tea.KeyMsg.String() writes them (e.g.
+// "enter", "ctrl+c", "shift+tab", "alt+x", "j")
+// Panics if a key isn't recognized, since that's a bug in the test
+func (driver *Driver) PressKeys(keys ...string) {
+	msgs := make([]tea.Msg, 0, len(keys))
+	for _, keyStr := range keys {
+		msgs = append(msgs, ParseKey(keyStr))
+	}
+	driver.Send(msgs...)
+}
+
+// Resize resizes the component the way a terminal resize would
+func (driver *Driver) Resize(width int, height int) {
+	driver.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// View renders the component, through the model so that any framework-level rendering is included
+func (driver *Driver) View() string {
+	return driver.model.View()
+}
+
+func (driver *Driver) GetComponent() bubble_bath.InteractiveComponent {
+	return driver.component
+}
+
+// GetProcessedMessages gets every message the component has been given so far, including those produced by commands
+func (driver *Driver) GetProcessedMessages() []tea.Msg {
+	return driver.processedMessages
+}
+
+// HasQuit indicates whether a tea.Quit has been run (e.g. because a quit sequence was pressed), after which the
+// driver ignores all further messages
+func (driver *Driver) HasQuit() bool {
+	return driver.hasQuit
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// runUntilSettled processes the messages and runs the commands, feeding each command's message back in (breadth-first,
+// like a real program's event loop but without the concurrency) until there's nothing left to do
+func (driver *Driver) runUntilSettled(cmds []tea.Cmd, msgs []tea.Msg) {
+	cmdQueue := cmds
+	msgQueue := msgs
+	numProcessed := 0
+	for len(msgQueue) > 0 || len(cmdQueue) > 0 {
+		if driver.hasQuit || numProcessed >= driver.maxMessagesPerSend {
+			return
+		}
+
+		if len(msgQueue) > 0 {
+			msg := msgQueue[0]
+			msgQueue = msgQueue[1:]
+
+			// Batches & sequences get flattened into their commands (run in order, for determinism)
+			if subCmds, ok := getSubCmds(msg); ok {
+				cmdQueue = append(cmdQueue, subCmds...)
+				continue
+			}
+			// BubbleTea doesn't export its quit message type, so we compare against the one that tea.Quit produces
+			if msg == tea.Quit() {
+				driver.hasQuit = true
+				continue
+			}
+
+			var cmd tea.Cmd
+			driver.model, cmd = driver.model.Update(msg)
+			driver.processedMessages = append(driver.processedMessages, msg)
+			numProcessed++
+			cmdQueue = append(cmdQueue, cmd)
+			continue
+		}
+
+		cmd := cmdQueue[0]
+		cmdQueue = cmdQueue[1:]
+		if msg, ok := driver.runCmd(cmd); ok {
+			msgQueue = append(msgQueue, msg)
+		}
+	}
+}
+
+// runCmd runs the command, giving up on it if it takes longer than the timeout
+func (driver *Driver) runCmd(cmd tea.Cmd) (tea.Msg, bool) {
+	if cmd == nil {
+		return nil, false
+	}
+
+	// Buffered so that a command that finishes after the timeout doesn't leak a blocked goroutine
+	resultChan := make(chan tea.Msg, 1)
+	go func() {
+		resultChan <- cmd()
+	}()
+
+	select {
+	case msg := <-resultChan:
+		return msg, msg != nil
+	case <-time.After(driver.cmdTimeout):
+		return nil, false
+	}
+}
+
+// getSubCmds gets the commands inside a tea.BatchMsg or BubbleTea's internal sequence message (which isn't exported,
+// hence the reflection)
+func getSubCmds(msg tea.Msg) ([]tea.Cmd, bool) {
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		return batch, true
+	}
+
+	cmdSliceType := reflect.TypeOf([]tea.Cmd{})
+	value := reflect.ValueOf(msg)
+	if value.Kind() != reflect.Slice || !value.Type().ConvertibleTo(cmdSliceType) {
+		return nil, false
+	}
+	return value.Convert(cmdSliceType).Interface().([]tea.Cmd), true
+}
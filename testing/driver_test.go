@@ -0,0 +1,176 @@
+package bubble_bath_testing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/flexbox"
+	"github.com/mieubrisse/bubble-bath/text_block"
+)
+
+type incrementMsg struct{}
+
+// counter counts typed runes, plus an increment for each incrementMsg that pressing "+" sets off, plus mouse events
+type counter struct {
+	numRunes       int
+	numIncrements  int
+	numMouseEvents int
+
+	isFocused bool
+	width     int
+	height    int
+}
+
+func (component *counter) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case incrementMsg:
+		component.numIncrements++
+	case tea.MouseMsg:
+		component.numMouseEvents++
+	case tea.KeyMsg:
+		if msg.String() == "+" {
+			increment := func() tea.Msg {
+				return incrementMsg{}
+			}
+			// Both a batch & a sequence, to check that the driver unpacks each
+			return tea.Batch(increment, tea.Sequence(increment, increment))
+		}
+		if msg.String() == "s" {
+			// Too slow for the driver's timeout, so it should get dropped
+			return tea.Tick(time.Second, func(time.Time) tea.Msg {
+				return incrementMsg{}
+			})
+		}
+		component.numRunes += len(msg.Runes)
+	}
+	return nil
+}
+
+func (component *counter) View() string {
+	return fmt.Sprintf("runes: %d, increments: %d", component.numRunes, component.numIncrements)
+}
+
+func (component *counter) Resize(width int, height int) {
+	component.width = width
+	component.height = height
+}
+
+func (component *counter) GetWidth() int {
+	return component.width
+}
+
+func (component *counter) GetHeight() int {
+	return component.height
+}
+
+func (component *counter) SetFocus(isFocused bool) tea.Cmd {
+	component.isFocused = isFocused
+	return nil
+}
+
+func (component *counter) IsFocused() bool {
+	return component.isFocused
+}
+
+func TestDriverSizesComponentOnStart(t *testing.T) {
+	component := &counter{}
+	New(component, 30, 4)
+	if component.GetWidth() != 30 || component.GetHeight() != 4 {
+		t.Errorf("Expected the component to be sized 30x4, but it was %vx%v", component.GetWidth(), component.GetHeight())
+	}
+}
+
+func TestDriverTypeSendsEachRune(t *testing.T) {
+	component := &counter{}
+	driver := New(component, 30, 1)
+	driver.Type("hello")
+	if component.numRunes != 5 {
+		t.Errorf("Expected 5 runes to have been typed, but got %v", component.numRunes)
+	}
+}
+
+func TestDriverRunsBatchesAndSequences(t *testing.T) {
+	component := &counter{}
+	driver := New(component, 30, 1)
+	driver.PressKeys("+")
+	if component.numIncrements != 3 {
+		t.Errorf("Expected 3 increments from the batched & sequenced commands, but got %v", component.numIncrements)
+	}
+}
+
+func TestDriverDropsSlowCommands(t *testing.T) {
+	component := &counter{}
+	driver := New(component, 30, 1)
+	driver.PressKeys("s")
+	if component.numIncrements != 0 {
+		t.Errorf("Expected the slow command to be dropped, but got %v increments", component.numIncrements)
+	}
+
+	component = &counter{}
+	driver = New(component, 30, 1, WithCmdTimeout(2*time.Second))
+	driver.PressKeys("s")
+	if component.numIncrements != 1 {
+		t.Errorf("Expected the slow command to run with a longer timeout, but got %v increments", component.numIncrements)
+	}
+}
+
+func TestDriverQuitsOnQuitSequence(t *testing.T) {
+	component := &counter{}
+	driver := New(component, 30, 1)
+	driver.PressKeys("ctrl+c", "a")
+	if !driver.HasQuit() {
+		t.Error("Expected the driver to have quit")
+	}
+	if component.numRunes != 0 {
+		t.Errorf("Expected keys after quitting to be ignored, but %v runes got through", component.numRunes)
+	}
+}
+
+func TestDriverViewMatchesGolden(t *testing.T) {
+	component := flexbox.New(
+		[]flexbox.FlexItem{
+			{Component: text_block.New("Title"), FixedSize: 1},
+			{Component: &counter{}, FlexWeight: 1},
+			{Component: text_block.New("Footer"), FixedSize: 1},
+		},
+		flexbox.WithDirection(flexbox.Vertical),
+	)
+	driver := New(component, 30, 5, WithBubbleBathOptions(bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap)))
+	driver.Type("abc")
+	driver.PressKeys("+")
+	driver.AssertViewMatchesGolden(t, "flexbox_with_counter")
+}
+
+func TestParseKey(t *testing.T) {
+	testCases := []struct {
+		keyStr   string
+		expected tea.KeyMsg
+	}{
+		{keyStr: "j", expected: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}},
+		{keyStr: "enter", expected: tea.KeyMsg{Type: tea.KeyEnter}},
+		{keyStr: "ctrl+c", expected: tea.KeyMsg{Type: tea.KeyCtrlC}},
+		{keyStr: "shift+tab", expected: tea.KeyMsg{Type: tea.KeyShiftTab}},
+		{keyStr: "alt+x", expected: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}, Alt: true}},
+		{keyStr: "space", expected: tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.keyStr, func(t *testing.T) {
+			actual := ParseKey(testCase.keyStr)
+			if actual.String() != testCase.expected.String() || actual.Alt != testCase.expected.Alt {
+				t.Errorf("Expected key '%v' but got '%v'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	actual := StripANSI("\x1b[1;38;5;12mbold blue\x1b[0m and \x1b]8;;https://example.com\x07a link\x1b]8;;\x07")
+	expected := "bold blue and a link"
+	if actual != expected {
+		t.Errorf("Expected '%v' but got '%v'", expected, actual)
+	}
+}
@@ -0,0 +1,146 @@
+package bubble_bath_testing
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// eventRecorder logs each phase of event dispatch that it takes part in, reporting the event handled in the phase it's
+// told to
+type eventRecorder struct {
+	name string
+
+	// Nil for the innermost recorder
+	child *eventRecorder
+
+	// Shared by every recorder in the tree
+	log *[]string
+
+	// The "<name> <phase>" entry at which the event gets handled
+	handlingEntry string
+
+	isFocused bool
+}
+
+func (recorder *eventRecorder) CaptureEvent(msg tea.Msg) (tea.Cmd, bool) {
+	return nil, recorder.record("capture")
+}
+
+func (recorder *eventRecorder) HandleEvent(msg tea.Msg) (tea.Cmd, bool) {
+	return nil, recorder.record("bubble")
+}
+
+func (recorder *eventRecorder) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		recorder.record("update")
+	}
+	return nil
+}
+
+func (recorder *eventRecorder) GetChildren() []bubble_bath.Component {
+	if recorder.child == nil {
+		return nil
+	}
+	return []bubble_bath.Component{recorder.child}
+}
+
+func (recorder *eventRecorder) View() string {
+	return ""
+}
+
+func (recorder *eventRecorder) Resize(width int, height int) {}
+
+func (recorder *eventRecorder) GetWidth() int {
+	return 0
+}
+
+func (recorder *eventRecorder) GetHeight() int {
+	return 0
+}
+
+func (recorder *eventRecorder) SetFocus(isFocused bool) tea.Cmd {
+	recorder.isFocused = isFocused
+	return nil
+}
+
+func (recorder *eventRecorder) IsFocused() bool {
+	return recorder.isFocused
+}
+
+func (recorder *eventRecorder) record(phase string) bool {
+	entry := recorder.name + " " + phase
+	*recorder.log = append(*recorder.log, entry)
+	return entry == recorder.handlingEntry
+}
+
+func TestEventDispatchCapturesDownwardsThenBubblesUpwards(t *testing.T) {
+	testCases := []struct {
+		name          string
+		handlingEntry string
+		expectedLog   []string
+	}{
+		{
+			name:          "an outer capturer stops the event reaching anything inside it",
+			handlingEntry: "outer capture",
+			expectedLog:   []string{"outer capture"},
+		},
+		{
+			name:          "an inner capturer gets the event after the outer one",
+			handlingEntry: "inner capture",
+			expectedLog:   []string{"outer capture", "inner capture"},
+		},
+		{
+			name:          "the innermost handler gets the event first when bubbling",
+			handlingEntry: "leaf bubble",
+			expectedLog:   []string{"outer capture", "inner capture", "leaf capture", "leaf bubble"},
+		},
+		{
+			name:          "an outer handler gets the event after the inner ones",
+			handlingEntry: "outer bubble",
+			expectedLog: []string{
+				"outer capture", "inner capture", "leaf capture", "leaf bubble", "inner bubble", "outer bubble",
+			},
+		},
+		{
+			name:          "an unhandled event goes to the app's update",
+			handlingEntry: "",
+			expectedLog: []string{
+				"outer capture", "inner capture", "leaf capture", "leaf bubble", "inner bubble", "outer bubble", "outer update",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			log := []string{}
+			newRecorder := func(name string, child *eventRecorder) *eventRecorder {
+				return &eventRecorder{
+					name:          name,
+					child:         child,
+					log:           &log,
+					handlingEntry: testCase.handlingEntry,
+					isFocused:     false,
+				}
+			}
+			outer := newRecorder("outer", newRecorder("inner", newRecorder("leaf", nil)))
+			driver := New(
+				outer,
+				10,
+				1,
+				WithBubbleBathOptions(
+					bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap),
+					bubble_bath.WithEventBubbling(),
+				),
+			)
+
+			log = log[:0]
+			driver.PressKeys("x")
+			if !reflect.DeepEqual(log, testCase.expectedLog) {
+				t.Errorf("Expected the dispatch to go %v but it went %v", testCase.expectedLog, log)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package bubble_bath_testing
+
+import (
+	"testing"
+
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/flexbox"
+)
+
+func TestFocusManagerMovesFocusBetweenLeaves(t *testing.T) {
+	counters := map[string]*counter{
+		"top left":     {},
+		"top right":    {},
+		"bottom left":  {},
+		"bottom right": {},
+	}
+	newRow := func(left *counter, right *counter) flexbox.Component {
+		return flexbox.New([]flexbox.FlexItem{
+			{Component: left, FlexWeight: 1},
+			{Component: right, FlexWeight: 1},
+		})
+	}
+	component := flexbox.New(
+		[]flexbox.FlexItem{
+			{Component: newRow(counters["top left"], counters["top right"]), FlexWeight: 1},
+			{Component: newRow(counters["bottom left"], counters["bottom right"]), FlexWeight: 1},
+		},
+		flexbox.WithDirection(flexbox.Vertical),
+	)
+	driver := New(component, 20, 10, WithBubbleBathOptions(bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap)))
+
+	// Each step runs in order, starting from the first leaf that init focuses
+	testCases := []struct {
+		name            string
+		keys            []string
+		expectedFocused string
+	}{
+		{name: "init focuses the first leaf", keys: []string{}, expectedFocused: "top left"},
+		{name: "tab moves to the next leaf", keys: []string{"tab"}, expectedFocused: "top right"},
+		{name: "tab crosses into the next container", keys: []string{"tab"}, expectedFocused: "bottom left"},
+		{name: "tab wraps around at the end", keys: []string{"tab", "tab"}, expectedFocused: "top left"},
+		{name: "shift+tab wraps around at the start", keys: []string{"shift+tab"}, expectedFocused: "bottom right"},
+		{name: "focus up moves to the leaf above", keys: []string{"ctrl+k"}, expectedFocused: "top right"},
+		{name: "focus left moves to the leaf beside", keys: []string{"ctrl+h"}, expectedFocused: "top left"},
+		{name: "focus left stops at the edge", keys: []string{"ctrl+h"}, expectedFocused: "top left"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			driver.PressKeys(testCase.keys...)
+			runeCountsBefore := map[string]int{}
+			for name, component := range counters {
+				runeCountsBefore[name] = component.numRunes
+			}
+			driver.Type("x")
+
+			for name, component := range counters {
+				isExpectedFocused := name == testCase.expectedFocused
+				if component.IsFocused() != isExpectedFocused {
+					t.Errorf("Expected '%v' to have focus %v, but it has focus %v", name, isExpectedFocused, component.IsFocused())
+				}
+
+				expectedNumNewRunes := 0
+				if isExpectedFocused {
+					expectedNumNewRunes = 1
+				}
+				if numNewRunes := component.numRunes - runeCountsBefore[name]; numNewRunes != expectedNumNewRunes {
+					t.Errorf("Expected '%v' to get %v typed runes but it got %v", name, expectedNumNewRunes, numNewRunes)
+				}
+			}
+		})
+	}
+
+	focusManager := driver.model.(interface {
+		GetFocusManager() *bubble_bath.FocusManager
+	}).GetFocusManager()
+	if focusManager.GetFocused() != counters["top left"] {
+		t.Errorf("Expected the focus manager to report the top left counter as focused, but got %v", focusManager.GetFocused())
+	}
+}
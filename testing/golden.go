@@ -0,0 +1,141 @@
+package bubble_bath_testing
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const (
+	updateFlagName = "update"
+
+	goldenFilesDirname   = "testdata"
+	goldenFileExtension  = ".golden"
+	goldenFilePermission = 0644
+	goldenDirPermission  = 0755
+)
+
+// Golden-file libraries commonly register the same flag, so we share theirs if it's already been registered
+func init() {
+	if flag.Lookup(updateFlagName) == nil {
+		flag.Bool(updateFlagName, false, "Rewrite golden files with the actual output rather than comparing against them")
+	}
+}
+
+// Matches CSI sequences (colors, cursor movement, etc.) and OSC sequences (e.g. hyperlinks, window titles)
+var ansiSequenceRegex = regexp.MustCompile("\x1b\\[[0-9;?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(\x07|\x1b\\\\)")
+
+type goldenConfig struct {
+	shouldPreserveANSI bool
+}
+
+type GoldenOption func(*goldenConfig)
+
+// WithANSIPreserved compares the ANSI escape sequences too (e.g. to catch styling regressions), rather than only the
+// printable text
+// Usually paired with the driver's WithColorProfile, since lipgloss won't emit colors in a test otherwise
+func WithANSIPreserved() GoldenOption {
+	return func(config *goldenConfig) {
+		config.shouldPreserveANSI = true
+	}
+}
+
+// AssertViewMatchesGolden checks the component's current view against the golden file with the given name
+func (driver *Driver) AssertViewMatchesGolden(t testing.TB, name string, opts ...GoldenOption) {
+	t.Helper()
+	AssertMatchesGolden(t, name, driver.View(), opts...)
+}
+
+// AssertMatchesGolden checks the output against the contents of testdata/NAME.golden, failing the test if they differ
+// Running the tests with -update writes the output to the golden file instead
+func AssertMatchesGolden(t testing.TB, name string, actual string, opts ...GoldenOption) {
+	t.Helper()
+
+	config := &goldenConfig{
+		shouldPreserveANSI: false,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if !config.shouldPreserveANSI {
+		actual = StripANSI(actual)
+	}
+
+	goldenFilepath := filepath.Join(goldenFilesDirname, name+goldenFileExtension)
+	if isUpdateEnabled() {
+		if err := os.MkdirAll(filepath.Dir(goldenFilepath), goldenDirPermission); err != nil {
+			t.Fatalf("An error occurred creating the directory for golden file '%v': %v", goldenFilepath, err)
+		}
+		if err := os.WriteFile(goldenFilepath, []byte(actual), goldenFilePermission); err != nil {
+			t.Fatalf("An error occurred writing golden file '%v': %v", goldenFilepath, err)
+		}
+		return
+	}
+
+	expectedBytes, err := os.ReadFile(goldenFilepath)
+	if err != nil {
+		t.Fatalf("An error occurred reading golden file '%v' (run with -%v to create it): %v", goldenFilepath, updateFlagName, err)
+	}
+	expected := string(expectedBytes)
+	if actual != expected {
+		t.Errorf(
+			"Output doesn't match golden file '%v' (run with -%v to accept the new output)\n%v",
+			goldenFilepath,
+			updateFlagName,
+			renderDiff(expected, actual),
+		)
+	}
+}
+
+// StripANSI removes all ANSI escape sequences from the string, leaving only the printable text
+func StripANSI(str string) string {
+	return ansiSequenceRegex.ReplaceAllString(str, "")
+}
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+func isUpdateEnabled() bool {
+	updateFlag := flag.Lookup(updateFlagName)
+	return updateFlag != nil && updateFlag.Value.String() == "true"
+}
+
+// renderDiff shows the expected & actual lines side by side, marking the ones that differ
+// Lines are wrapped in | characters so that trailing whitespace is visible
+func renderDiff(expected string, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	numLines := len(expectedLines)
+	if len(actualLines) > numLines {
+		numLines = len(actualLines)
+	}
+
+	var result strings.Builder
+	for idx := 0; idx < numLines; idx++ {
+		expectedLine, actualLine := "", ""
+		hasExpectedLine, hasActualLine := idx < len(expectedLines), idx < len(actualLines)
+		if hasExpectedLine {
+			expectedLine = expectedLines[idx]
+		}
+		if hasActualLine {
+			actualLine = actualLines[idx]
+		}
+		if hasExpectedLine && hasActualLine && expectedLine == actualLine {
+			result.WriteString("  |" + actualLine + "|\n")
+			continue
+		}
+		if hasExpectedLine {
+			result.WriteString("- |" + expectedLine + "|\n")
+		}
+		if hasActualLine {
+			result.WriteString("+ |" + actualLine + "|\n")
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,56 @@
+package bubble_bath_testing
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BubbleTea doesn't export its key names, so we recover them by asking each key type for its name
+// The range comfortably covers both the control keys (non-negative) and the special keys (negative)
+var keyTypesByName = func() map[string]tea.KeyType {
+	result := map[string]tea.KeyType{}
+	for keyTypeInt := -200; keyTypeInt <= 127; keyTypeInt++ {
+		keyType := tea.KeyType(keyTypeInt)
+		if name := keyType.String(); name != "" {
+			result[name] = keyType
+		}
+	}
+	return result
+}()
+
+// ParseKey turns a key written the way tea.KeyMsg.String() writes it (e.g. "enter", "ctrl+c", "alt+x", "j") back into
+// the tea.KeyMsg that would produce it
+// Panics if the key isn't recognized, since that's a bug in the test
+func ParseKey(keyStr string) tea.KeyMsg {
+	isAlt := false
+	if keyStr != "alt+" && strings.HasPrefix(keyStr, "alt+") {
+		isAlt = true
+		keyStr = strings.TrimPrefix(keyStr, "alt+")
+	}
+
+	if keyStr == " " || keyStr == "space" {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}, Alt: isAlt}
+	}
+	if keyType, found := keyTypesByName[keyStr]; found {
+		return tea.KeyMsg{Type: keyType, Alt: isAlt}
+	}
+	if utf8.RuneCountInString(keyStr) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keyStr), Alt: isAlt}
+	}
+	panic(fmt.Sprintf("Unrecognized key '%v'", keyStr))
+}
+
+func runeToKeyMsg(r rune) tea.KeyMsg {
+	switch r {
+	case ' ':
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+	case '\n':
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case '\t':
+		return tea.KeyMsg{Type: tea.KeyTab}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
@@ -0,0 +1,56 @@
+package bubble_bath_testing
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/flexbox"
+)
+
+// clickableCounter is a counter that handles the mouse events routed to it
+type clickableCounter struct {
+	counter
+
+	numHandledMouseEvents int
+}
+
+func (component *clickableCounter) HandleMouse(msg tea.MouseMsg) tea.Cmd {
+	component.numHandledMouseEvents++
+	return nil
+}
+
+func TestMouseRouterPassesUnhandledEventsToApp(t *testing.T) {
+	clickable := &clickableCounter{}
+	unclickable := &counter{}
+	component := flexbox.New([]flexbox.FlexItem{
+		{Component: clickable, FlexWeight: 1},
+		{Component: unclickable, FlexWeight: 1},
+	})
+	driver := New(
+		component,
+		20,
+		1,
+		WithBubbleBathOptions(
+			bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap),
+			bubble_bath.WithMouseRouting(),
+		),
+	)
+
+	driver.Send(tea.MouseMsg{X: 2, Y: 0, Type: tea.MouseLeft}, tea.MouseMsg{X: 2, Y: 0, Type: tea.MouseRelease})
+	if clickable.numHandledMouseEvents != 2 {
+		t.Errorf("Expected the component under the pointer to handle 2 mouse events, but it handled %v", clickable.numHandledMouseEvents)
+	}
+	if clickable.numMouseEvents != 0 {
+		t.Errorf("Expected handled mouse events not to reach the app, but %v did", clickable.numMouseEvents)
+	}
+
+	// Nothing under the pointer handles the mouse, so the app gets the click (which it routes to the newly-focused child)
+	driver.Send(tea.MouseMsg{X: 15, Y: 0, Type: tea.MouseLeft})
+	if !unclickable.IsFocused() {
+		t.Errorf("Expected clicking the unclickable counter to focus it, but it isn't focused")
+	}
+	if unclickable.numMouseEvents != 1 {
+		t.Errorf("Expected the unhandled click to reach the app, but %v mouse events did", unclickable.numMouseEvents)
+	}
+}
@@ -0,0 +1,61 @@
+package bubble_bath_testing
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+func TestOverlayStackSendsInputToTopOverlayOnly(t *testing.T) {
+	base := &counter{}
+	stack := bubble_bath.NewOverlayStack(base)
+	driver := New(stack, 30, 10)
+
+	bottomOverlay := &counter{}
+	topOverlay := &counter{}
+	for _, overlay := range []*counter{bottomOverlay, topOverlay} {
+		driver.Send(bubble_bath.PushOverlayMsg{Overlay: bubble_bath.Overlay{
+			Component: overlay,
+			Position:  bubble_bath.OverlayCentered(),
+			Width:     10,
+			Height:    3,
+		}})
+	}
+
+	driver.Type("ab")
+	driver.Send(tea.MouseMsg{X: 0, Y: 0, Type: tea.MouseLeft})
+	driver.Send(incrementMsg{})
+
+	testCases := []struct {
+		name                   string
+		component              *counter
+		expectedNumRunes       int
+		expectedNumMouseEvents int
+		expectedNumIncrements  int
+	}{
+		{name: "base", component: base, expectedNumRunes: 0, expectedNumMouseEvents: 0, expectedNumIncrements: 1},
+		{name: "bottom overlay", component: bottomOverlay, expectedNumRunes: 0, expectedNumMouseEvents: 0, expectedNumIncrements: 1},
+		{name: "top overlay", component: topOverlay, expectedNumRunes: 2, expectedNumMouseEvents: 1, expectedNumIncrements: 1},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.component.numRunes != testCase.expectedNumRunes {
+				t.Errorf("Expected %v runes but got %v", testCase.expectedNumRunes, testCase.component.numRunes)
+			}
+			if testCase.component.numMouseEvents != testCase.expectedNumMouseEvents {
+				t.Errorf("Expected %v mouse events but got %v", testCase.expectedNumMouseEvents, testCase.component.numMouseEvents)
+			}
+			if testCase.component.numIncrements != testCase.expectedNumIncrements {
+				t.Errorf("Expected %v increments but got %v", testCase.expectedNumIncrements, testCase.component.numIncrements)
+			}
+		})
+	}
+
+	// Closing the top overlay makes the one beneath it modal
+	driver.Send(bubble_bath.PopOverlayMsg{})
+	driver.Type("c")
+	if bottomOverlay.numRunes != 1 {
+		t.Errorf("Expected the overlay beneath the closed one to get the typed rune, but it got %v runes", bottomOverlay.numRunes)
+	}
+}
@@ -0,0 +1,149 @@
+package bubble_bath_testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+	"github.com/mieubrisse/bubble-bath/router"
+)
+
+// mountedCounter is a counter that records when it's mounted & unmounted
+type mountedCounter struct {
+	counter
+
+	isMounted   bool
+	unmountTime time.Time
+}
+
+func (component *mountedCounter) Mount(ctx bubble_bath.MountContext) tea.Cmd {
+	component.isMounted = true
+	return nil
+}
+
+func (component *mountedCounter) Unmount() tea.Cmd {
+	component.isMounted = false
+	component.unmountTime = time.Now()
+	return nil
+}
+
+func TestRouterPushPopAndBack(t *testing.T) {
+	home := &mountedCounter{}
+	details := &mountedCounter{}
+	settings := &mountedCounter{}
+	component := router.New(
+		router.Screen{Name: "Home", Component: home},
+		router.WithBackBinding(key.NewBinding(key.WithKeys("esc"))),
+	)
+	driver := New(
+		component,
+		20,
+		5,
+		WithBubbleBathOptions(
+			bubble_bath.WithFocusManagement(bubble_bath.DefaultFocusKeyMap),
+			bubble_bath.WithEventBubbling(),
+		),
+	)
+
+	// Each step runs in order
+	testCases := []struct {
+		name               string
+		msgs               []tea.Msg
+		keys               []string
+		expectedActive     *mountedCounter
+		expectedNumScreens int
+		expectedMounted    []*mountedCounter
+		expectedUnmounted  []*mountedCounter
+	}{
+		{
+			name:               "starts on the root screen",
+			expectedActive:     home,
+			expectedNumScreens: 1,
+			expectedMounted:    []*mountedCounter{home},
+			expectedUnmounted:  []*mountedCounter{details, settings},
+		},
+		{
+			name:               "push mounts the new screen on top",
+			msgs:               []tea.Msg{router.PushMsg{Screen: router.Screen{Name: "Details", Component: details}}},
+			expectedActive:     details,
+			expectedNumScreens: 2,
+			expectedMounted:    []*mountedCounter{home, details},
+			expectedUnmounted:  []*mountedCounter{settings},
+		},
+		{
+			name:               "replace unmounts the replaced screen",
+			msgs:               []tea.Msg{router.ReplaceMsg{Screen: router.Screen{Name: "Settings", Component: settings}}},
+			expectedActive:     settings,
+			expectedNumScreens: 2,
+			expectedMounted:    []*mountedCounter{home, settings},
+			expectedUnmounted:  []*mountedCounter{details},
+		},
+		{
+			name:               "the back key pops the active screen",
+			keys:               []string{"esc"},
+			expectedActive:     home,
+			expectedNumScreens: 1,
+			expectedMounted:    []*mountedCounter{home},
+			expectedUnmounted:  []*mountedCounter{details, settings},
+		},
+		{
+			name:               "the root screen is never popped",
+			msgs:               []tea.Msg{router.PopMsg{}},
+			keys:               []string{"esc"},
+			expectedActive:     home,
+			expectedNumScreens: 1,
+			expectedMounted:    []*mountedCounter{home},
+			expectedUnmounted:  []*mountedCounter{details, settings},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			driver.Send(testCase.msgs...)
+			driver.PressKeys(testCase.keys...)
+
+			if numScreens := len(component.GetScreens()); numScreens != testCase.expectedNumScreens {
+				t.Errorf("Expected %v screens but got %v", testCase.expectedNumScreens, numScreens)
+			}
+			if !testCase.expectedActive.IsFocused() {
+				t.Errorf("Expected the active screen to be focused, but it isn't")
+			}
+			numRunesBefore := testCase.expectedActive.numRunes
+			driver.Type("x")
+			if testCase.expectedActive.numRunes != numRunesBefore+1 {
+				t.Errorf("Expected the active screen to get the typed rune, but it didn't")
+			}
+			for _, screen := range testCase.expectedMounted {
+				if !screen.isMounted {
+					t.Errorf("Expected screen %p to be mounted, but it isn't", screen)
+				}
+			}
+			for _, screen := range testCase.expectedUnmounted {
+				if screen.isMounted {
+					t.Errorf("Expected screen %p to be unmounted, but it's mounted", screen)
+				}
+			}
+		})
+	}
+}
+
+func TestRouterUnmountsPoppedScreenAfterTransition(t *testing.T) {
+	transitionDuration := 50 * time.Millisecond
+	home := &mountedCounter{}
+	details := &mountedCounter{}
+	component := router.New(router.Screen{Name: "Home", Component: home}, router.WithTransition(transitionDuration))
+
+	// Long enough for the animation ticker's ticks, so that the transition runs to completion within each Send
+	driver := New(component, 20, 5, WithCmdTimeout(10*transitionDuration))
+	driver.Send(router.PushMsg{Screen: router.Screen{Name: "Details", Component: details}})
+
+	popTime := time.Now()
+	driver.Send(router.PopMsg{})
+	if details.isMounted {
+		t.Fatalf("Expected the popped screen to be unmounted once its transition finished, but it's still mounted")
+	}
+	if elapsed := details.unmountTime.Sub(popTime); elapsed < transitionDuration {
+		t.Errorf("Expected the popped screen to stay mounted while it slid out, but it was unmounted after %v", elapsed)
+	}
+}
@@ -0,0 +1,122 @@
+package bubble_bath_testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// taskStarter starts its task when "s" is pressed, and records what it hears back
+type taskStarter struct {
+	counter
+
+	runner *bubble_bath.TaskRunner
+
+	taskFunc bubble_bath.TaskFunc
+
+	task *bubble_bath.Task
+
+	doneMsgs []bubble_bath.TaskDoneMsg
+
+	numUnhandledEscs int
+}
+
+func (starter *taskStarter) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if cmd, isHandled := starter.runner.HandleKey(msg); isHandled {
+			return cmd
+		}
+		switch msg.String() {
+		case "s":
+			task, cmd := starter.runner.Start("test task", starter.taskFunc)
+			starter.task = task
+			return cmd
+		case "esc":
+			starter.numUnhandledEscs++
+		}
+	case bubble_bath.TaskDoneMsg:
+		starter.doneMsgs = append(starter.doneMsgs, msg)
+	}
+	return nil
+}
+
+func TestTaskRunnerDeliversProgressAndResult(t *testing.T) {
+	starter := &taskStarter{
+		counter: counter{},
+		runner:  bubble_bath.NewTaskRunner(bubble_bath.DefaultTaskKeyMap),
+		taskFunc: func(ctx context.Context, reportProgress func(progress bubble_bath.TaskProgress)) (any, error) {
+			reportProgress(bubble_bath.TaskProgress{Completed: 1, Total: 2, Message: "halfway"})
+			reportProgress(bubble_bath.TaskProgress{Completed: 2, Total: 2, Message: "finished"})
+			return "result", nil
+		},
+		task:             nil,
+		doneMsgs:         nil,
+		numUnhandledEscs: 0,
+	}
+	driver := New(starter, 10, 1, WithCmdTimeout(time.Second))
+
+	driver.PressKeys("s")
+	if len(starter.doneMsgs) != 1 {
+		t.Fatalf("Expected one done message but got %v", len(starter.doneMsgs))
+	}
+	doneMsg := starter.doneMsgs[0]
+	if doneMsg.Task != starter.task {
+		t.Errorf("Expected the done message to be for the started task but it was for task '%v'", doneMsg.Task.GetName())
+	}
+	if doneMsg.Result != "result" || doneMsg.Err != nil {
+		t.Errorf("Expected the task to finish with 'result' and no error but got '%v' and '%v'", doneMsg.Result, doneMsg.Err)
+	}
+	if progress := starter.task.GetProgress(); progress.Completed != 2 {
+		t.Errorf("Expected the task's latest progress to be its last report but got %v", progress)
+	}
+	if numRunning := len(starter.runner.GetRunningTasks()); numRunning != 0 {
+		t.Errorf("Expected no tasks to be running but got %v", numRunning)
+	}
+}
+
+func TestTaskRunnerCancelsOnCancelKey(t *testing.T) {
+	starter := &taskStarter{
+		counter: counter{},
+		runner:  bubble_bath.NewTaskRunner(bubble_bath.DefaultTaskKeyMap),
+		taskFunc: func(ctx context.Context, reportProgress func(progress bubble_bath.TaskProgress)) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		task:             nil,
+		doneMsgs:         nil,
+		numUnhandledEscs: 0,
+	}
+	// The task blocks until it's cancelled, so the driver gives up on its messages rather than waiting
+	driver := New(starter, 10, 1, WithCmdTimeout(10*time.Millisecond))
+
+	driver.PressKeys("s")
+	if numRunning := len(starter.runner.GetRunningTasks()); numRunning != 1 {
+		t.Fatalf("Expected the started task to be running but %v tasks are", numRunning)
+	}
+
+	driver.PressKeys("esc")
+	if starter.numUnhandledEscs != 0 {
+		t.Errorf("Expected the runner to take the cancel key while a task is running, but the component got it")
+	}
+	deadline := time.Now().Add(time.Second)
+	for !starter.task.IsDone() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !starter.task.IsCancelled() {
+		t.Errorf("Expected the task to be cancelled but it wasn't")
+	}
+	if err := starter.task.GetError(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled task's error to be %v but got %v", context.Canceled, err)
+	}
+
+	// With nothing left to cancel, the key is free for the component
+	driver.PressKeys("esc")
+	if starter.numUnhandledEscs != 1 {
+		t.Errorf("Expected the component to get the cancel key once nothing is running, but it got it %v times", starter.numUnhandledEscs)
+	}
+}
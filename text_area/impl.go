@@ -24,6 +24,9 @@ const (
 	defaultCharLimit = 400
 	maxHeight        = 99
 	maxWidth         = 500
+
+	// cursorBlinkFPS is how many times per second the cursor blinks on or off.
+	cursorBlinkFPS = 2
 )
 
 // Paste is a tea.Cmd for pasting from the clipboard into the text input.
@@ -78,9 +81,17 @@ type implementation struct {
 	// when switching focus states.
 	style *Style
 
-	// Cursor is the text area cursor.
+	// theme is the theme that FocusedStyle and BlurredStyle were derived from.
+	theme bubble_bath.Theme
+
+	// Cursor is the text area cursor. Its blinking is driven by blinkAnimation
+	// rather than by its own ticks, so it's kept in static mode.
 	Cursor cursor.Model
 
+	// blinkAnimation toggles the cursor on and off while the text area is
+	// focused.
+	blinkAnimation *bubble_bath.Animation
+
 	// CharLimit is the maximum number of characters this input element will
 	// accept. If 0 or less, there's no limit.
 	CharLimit int
@@ -133,19 +144,26 @@ type implementation struct {
 func New() Component {
 	vp := viewport.New(0, 0)
 	vp.KeyMap = viewport.KeyMap{}
-	cur := cursor.New()
 
-	focusedStyle, blurredStyle := DefaultStyles()
+	// The program applies its theme before the first render, so there's no need to query the terminal here
+	theme := bubble_bath.DarkTheme
+	focusedStyle, blurredStyle := DefaultStyles(theme)
+
+	cur := cursor.New()
+	cur.SetMode(cursor.CursorStatic)
+	cur.Style = defaultCursorStyle(theme)
 
 	m := &implementation{
 		CharLimit:            defaultCharLimit,
 		Prompt:               lipgloss.ThickBorder().Left + " ",
-		style:                &blurredStyle,
 		FocusedStyle:         focusedStyle,
 		BlurredStyle:         blurredStyle,
+		style:                nil,
+		theme:                theme,
 		EndOfBufferCharacter: '~',
 		ShowLineNumbers:      true,
 		Cursor:               cur,
+		blinkAnimation:       bubble_bath.NewAnimation(cursorBlinkFPS),
 		KeyMap:               DefaultKeyMap,
 
 		value:            make([][]rune, minHeight, maxHeight),
@@ -156,6 +174,7 @@ func New() Component {
 
 		viewport: &vp,
 	}
+	m.style = &m.BlurredStyle
 
 	m.Resize(defaultWidth, defaultHeight)
 
@@ -225,13 +244,66 @@ func (m *implementation) SetFocus(isFocused bool) tea.Cmd {
 	var cmd tea.Cmd
 	if isFocused {
 		m.style = &m.FocusedStyle
-		cmd = m.Cursor.Focus()
+		cmd = tea.Batch(m.Cursor.Focus(), m.blinkAnimation.Start())
 	} else {
 		m.style = &m.BlurredStyle
+		m.blinkAnimation.Stop()
 	}
 	return cmd
 }
 
+// GetKeyMap returns the key bindings recognized by the textarea.
+func (m *implementation) GetKeyMap() KeyMap {
+	return m.KeyMap
+}
+
+// SetKeyMap replaces the key bindings recognized by the textarea.
+func (m *implementation) SetKeyMap(keyMap KeyMap) {
+	m.KeyMap = keyMap
+}
+
+// ApplyKeyMapConfig overrides the textarea's bindings with the configured ones.
+func (m *implementation) ApplyKeyMapConfig(config *bubble_bath.KeyMapConfig) {
+	config.Apply(KeyMapType, &m.KeyMap)
+}
+
+// ShortHelp returns the textarea's most important key bindings.
+func (m *implementation) ShortHelp() []key.Binding {
+	return m.KeyMap.ShortHelp()
+}
+
+// FullHelp returns all the textarea's key bindings.
+func (m *implementation) FullHelp() [][]key.Binding {
+	return m.KeyMap.FullHelp()
+}
+
+// GetStyles returns the styles used in the focused and blurred states.
+func (m *implementation) GetStyles() (Style, Style) {
+	return m.FocusedStyle, m.BlurredStyle
+}
+
+// SetStyles replaces the styles used in the focused and blurred states. They're
+// kept across theme changes, except for any parts left at the theme's defaults.
+func (m *implementation) SetStyles(focused Style, blurred Style) {
+	m.FocusedStyle = focused
+	m.BlurredStyle = blurred
+}
+
+// SetTheme re-derives the focused and blurred styles from the theme. Styles
+// that were customized (i.e. no longer match the old theme's defaults) are
+// left alone.
+func (m *implementation) SetTheme(theme bubble_bath.Theme) {
+	if theme == m.theme {
+		return
+	}
+	oldFocused, oldBlurred := DefaultStyles(m.theme)
+	newFocused, newBlurred := DefaultStyles(theme)
+	m.FocusedStyle = m.FocusedStyle.rethemed(oldFocused, newFocused)
+	m.BlurredStyle = m.BlurredStyle.rethemed(oldBlurred, newBlurred)
+	m.Cursor.Style = rethemed(m.Cursor.Style, defaultCursorStyle(m.theme), defaultCursorStyle(theme))
+	m.theme = theme
+}
+
 // GetLineInfo returns the number of characters from the start of the
 // (soft-wrapped) line and the (soft-wrapped) line width.
 func (m *implementation) GetLineInfo() LineInfo {
@@ -293,21 +365,13 @@ func (m *implementation) GetHeight() int {
 func (m *implementation) Resize(width int, height int) {
 	m.viewport.Width = bubble_bath.Clamp(width, minWidth, maxWidth)
 
-	// Since the width of the textarea input is dependant on the width of the
-	// prompt and line numbers, we need to calculate it by subtracting.
-	inputWidth := width
-	if m.ShowLineNumbers {
-		inputWidth -= rw.StringWidth(fmt.Sprintf(m.lineNumberFormat, 0))
-	}
-
-	// Account for base style borders and padding.
-	inputWidth -= m.style.Base.GetHorizontalFrameSize()
-
 	if m.promptFunc == nil {
 		m.promptWidth = rw.StringWidth(m.Prompt)
 	}
 
-	inputWidth -= m.promptWidth
+	// Since the width of the textarea input is dependant on the width of the
+	// prompt and line numbers, we need to calculate it by subtracting.
+	inputWidth := width - m.getNonInputWidth()
 	m.width = bubble_bath.Clamp(inputWidth, minWidth, maxWidth)
 
 	m.height = bubble_bath.Clamp(height, minHeight, maxHeight)
@@ -315,8 +379,38 @@ func (m *implementation) Resize(width int, height int) {
 	m.viewport.Height = bubble_bath.Clamp(height, minHeight, maxHeight)
 }
 
+func (m *implementation) GetMinimumIntrinsicWidth() int {
+	return m.getNonInputWidth() + minWidth
+}
+
+func (m *implementation) GetMaximumIntrinsicWidth() int {
+	longestLineWidth := 0
+	for _, line := range m.value {
+		longestLineWidth = bubble_bath.GetMaxInt(longestLineWidth, rw.StringWidth(string(line)))
+	}
+
+	// The extra 1 leaves room for the cursor when it's sitting just past the end of the line
+	inputWidth := bubble_bath.Clamp(longestLineWidth+1, minWidth, maxWidth)
+	return m.getNonInputWidth() + inputWidth
+}
+
+func (m *implementation) GetHeightGivenWidth(width int) int {
+	inputWidth := bubble_bath.Clamp(width-m.getNonInputWidth(), minWidth, maxWidth)
+
+	numDisplayLines := 0
+	for _, line := range m.value {
+		numDisplayLines += len(wrap(line, inputWidth))
+	}
+	return bubble_bath.Clamp(numDisplayLines, minHeight, maxHeight)
+}
+
 // Update is the Bubble Tea update loop.
 func (m *implementation) Update(msg tea.Msg) tea.Cmd {
+	if themeChangedMsg, ok := msg.(bubble_bath.ThemeChangedMsg); ok {
+		m.SetTheme(themeChangedMsg.Current)
+		return nil
+	}
+
 	if !m.focus {
 		m.Cursor.Blur()
 		return nil
@@ -436,17 +530,68 @@ func (m *implementation) Update(msg tea.Msg) tea.Cmd {
 
 	newRow, newCol := m.cursorLineNumber(), m.col
 	m.Cursor, cmd = m.Cursor.Update(msg)
+	cmds = append(cmds, cmd)
 	if newRow != oldRow || newCol != oldCol {
-		m.Cursor.Blink = false
-		cmd = m.Cursor.BlinkCmd()
+		m.resetBlink()
 	}
-	cmds = append(cmds, cmd)
 
 	m.repositionView()
 
 	return tea.Batch(cmds...)
 }
 
+// HandleMouse moves the cursor to the clicked (or dragged-to) position, and moves it up & down with the mouse wheel
+func (m *implementation) HandleMouse(msg tea.MouseMsg) tea.Cmd {
+	oldRow, oldCol := m.cursorLineNumber(), m.col
+
+	switch msg.Type {
+	case tea.MouseLeft:
+		topFrameSize := m.style.Base.GetMarginTop() + m.style.Base.GetBorderTopSize() + m.style.Base.GetPaddingTop()
+		displayLine := bubble_bath.Clamp(m.viewport.YOffset+msg.Y-topFrameSize, 0, m.getNumDisplayLines()-1)
+		inputStartX := m.getNonInputWidth() - m.getRightFrameSize()
+		charOffset := bubble_bath.GetMaxInt(0, msg.X-inputStartX)
+		m.moveCursorToDisplayPosition(displayLine, charOffset)
+	case tea.MouseWheelUp:
+		m.MoveCursorUp(false)
+	case tea.MouseWheelDown:
+		m.MoveCursorDown(false)
+	default:
+		return nil
+	}
+
+	m.repositionView()
+	if m.cursorLineNumber() != oldRow || m.col != oldCol {
+		m.resetBlink()
+	}
+	return nil
+}
+
+// HandleAnimationFrame blinks the cursor.
+func (m *implementation) HandleAnimationFrame(msg bubble_bath.AnimationFrameMsg) tea.Cmd {
+	if msg.IsFrameFor(m.blinkAnimation) && m.focus {
+		m.Cursor.Blink = !m.Cursor.Blink
+	}
+	return nil
+}
+
+// resetBlink shows the cursor and holds off its next blink, so that it stays
+// visible while it's being moved.
+func (m *implementation) resetBlink() {
+	m.Cursor.Blink = false
+	m.blinkAnimation.Reset()
+}
+
+// HandleEvent edits the textarea for typed text and the textarea's editing keys,
+// leaving every other key (e.g. esc) to bubble up to whatever the textarea is
+// part of.
+func (m *implementation) HandleEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focus || !m.isEditingKey(keyMsg) {
+		return nil, false
+	}
+	return m.Update(msg), true
+}
+
 // View renders the text area in its current state.
 func (m *implementation) View() string {
 	if m.GetValue() == "" && m.row == 0 && m.col == 0 && m.Placeholder != "" {
@@ -556,6 +701,26 @@ func (m *implementation) repositionView() {
 	}
 }
 
+// getNonInputWidth gets the width taken up by everything on a line that isn't the user's input (prompt, line numbers,
+// and base style borders and padding)
+func (m *implementation) getNonInputWidth() int {
+	result := 0
+	if m.ShowLineNumbers {
+		result += rw.StringWidth(fmt.Sprintf(m.lineNumberFormat, 0))
+	}
+
+	// Account for base style borders and padding.
+	result += m.style.Base.GetHorizontalFrameSize()
+
+	promptWidth := m.promptWidth
+	if m.promptFunc == nil {
+		promptWidth = rw.StringWidth(m.Prompt)
+	}
+	result += promptWidth
+
+	return result
+}
+
 func (m *implementation) getPromptString(displayLine int) (prompt string) {
 	prompt = m.Prompt
 	if m.promptFunc == nil {
@@ -609,6 +774,55 @@ func (m *implementation) placeholderView() string {
 	return m.style.Base.Render(m.viewport.View())
 }
 
+// getRightFrameSize gets the width of the base style's margin, border, and padding on the right-hand side
+func (m *implementation) getRightFrameSize() int {
+	return m.style.Base.GetMarginRight() + m.style.Base.GetBorderRightSize() + m.style.Base.GetPaddingRight()
+}
+
+// getNumDisplayLines gets the number of (soft-wrapped) lines that the value takes up
+func (m *implementation) getNumDisplayLines() int {
+	result := 0
+	for _, line := range m.value {
+		result += len(wrap(line, m.width))
+	}
+	return result
+}
+
+// moveCursorToDisplayPosition moves the cursor to the rune at the given character offset within the given
+// (soft-wrapped) display line, or as close as possible if the offset is past the end of the line
+func (m *implementation) moveCursorToDisplayPosition(displayLine int, charOffset int) {
+	for row, line := range m.value {
+		wrappedLines := wrap(line, m.width)
+		if displayLine >= len(wrappedLines) {
+			displayLine -= len(wrappedLines)
+			continue
+		}
+
+		startColumn := 0
+		for _, wrappedLine := range wrappedLines[:displayLine] {
+			startColumn += len(wrappedLine)
+		}
+
+		// Soft-wrapped lines carry a trailing space, which the cursor shouldn't land past unless it's the last line
+		wrappedLine := wrappedLines[displayLine]
+		maxColumnOffset := len(wrappedLine) - 1
+		if displayLine == len(wrappedLines)-1 {
+			maxColumnOffset = len(line) - startColumn
+		}
+
+		columnOffset := 0
+		offset := 0
+		for columnOffset < maxColumnOffset && offset+rw.RuneWidth(wrappedLine[columnOffset]) <= charOffset {
+			offset += rw.RuneWidth(wrappedLine[columnOffset])
+			columnOffset++
+		}
+
+		m.row = row
+		m.SetCursorColumn(startColumn + columnOffset)
+		return
+	}
+}
+
 // cursorLineNumber returns the line number that the cursor is on.
 // This accounts for soft wrapped lines.
 func (m *implementation) cursorLineNumber() int {
@@ -623,6 +837,20 @@ func (m *implementation) cursorLineNumber() int {
 }
 
 // Wrap a rune string into an array of rune strings
+// isEditingKey reports whether the key types text or matches one of the
+// textarea's bindings.
+func (m *implementation) isEditingKey(msg tea.KeyMsg) bool {
+	if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+		return true
+	}
+	for _, binding := range m.KeyMap.GetBindingsByAction() {
+		if key.Matches(msg, *binding) {
+			return true
+		}
+	}
+	return false
+}
+
 func wrap(runes []rune, width int) [][]rune {
 	var (
 		lines  = [][]rune{{}}
@@ -4,6 +4,13 @@ import bubble_bath "github.com/mieubrisse/bubble-bath"
 
 type Component interface {
 	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.MouseHandlingComponent
+	bubble_bath.ThemedComponent
+	bubble_bath.KeyBindingProvider
+	bubble_bath.KeyRemappableComponent
+	bubble_bath.EventHandler
+	bubble_bath.AnimatedComponent
 
 	/* ---- getters ----- */
 
@@ -14,6 +21,16 @@ type Component interface {
 	GetCursorColumn() int
 	GetCursorRow() int
 
+	/* ---- key bindings ----- */
+
+	GetKeyMap() KeyMap
+	SetKeyMap(keyMap KeyMap)
+
+	/* ---- styling ----- */
+
+	GetStyles() (focused Style, blurred Style)
+	SetStyles(focused Style, blurred Style)
+
 	/* ---- prompt func ----- */
 
 	SetPromptFunc(promptWidth int, fn func(lineIdx int) string)
@@ -1,6 +1,12 @@
 package textarea
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// KeyMapType is the name that key map config files use for the textarea's bindings.
+const KeyMapType = "text_area"
 
 // KeyMap is the key bindings for different actions within the textarea.
 type KeyMap struct {
@@ -28,22 +34,84 @@ type KeyMap struct {
 // DefaultKeyMap is the default set of key bindings for navigating and acting
 // upon the textarea.
 var DefaultKeyMap = KeyMap{
-	CharacterForward:        key.NewBinding(key.WithKeys("right", "ctrl+f")),
-	CharacterBackward:       key.NewBinding(key.WithKeys("left", "ctrl+b")),
-	WordForward:             key.NewBinding(key.WithKeys("alt+right", "alt+f")),
-	WordBackward:            key.NewBinding(key.WithKeys("alt+left", "alt+b")),
-	LineNext:                key.NewBinding(key.WithKeys("down", "ctrl+n")),
-	LinePrevious:            key.NewBinding(key.WithKeys("up", "ctrl+p")),
-	DeleteWordBackward:      key.NewBinding(key.WithKeys("alt+backspace", "ctrl+w")),
-	DeleteWordForward:       key.NewBinding(key.WithKeys("alt+delete", "alt+d")),
-	DeleteAfterCursor:       key.NewBinding(key.WithKeys("ctrl+k")),
-	DeleteBeforeCursor:      key.NewBinding(key.WithKeys("ctrl+u")),
-	InsertNewline:           key.NewBinding(key.WithKeys("enter", "ctrl+m")),
-	DeleteCharacterBackward: key.NewBinding(key.WithKeys("backspace", "ctrl+h")),
-	DeleteCharacterForward:  key.NewBinding(key.WithKeys("delete", "ctrl+d")),
-	LineStart:               key.NewBinding(key.WithKeys("home", "ctrl+a")),
-	LineEnd:                 key.NewBinding(key.WithKeys("end", "ctrl+e")),
-	Paste:                   key.NewBinding(key.WithKeys("ctrl+v")),
-	InputBegin:              key.NewBinding(key.WithKeys("alt+<", "ctrl+home")),
-	InputEnd:                key.NewBinding(key.WithKeys("alt+>", "ctrl+end")),
+	CharacterForward:        key.NewBinding(key.WithKeys("right", "ctrl+f"), key.WithHelp("right", "character forward")),
+	CharacterBackward:       key.NewBinding(key.WithKeys("left", "ctrl+b"), key.WithHelp("left", "character backward")),
+	WordForward:             key.NewBinding(key.WithKeys("alt+right", "alt+f"), key.WithHelp("alt+right", "word forward")),
+	WordBackward:            key.NewBinding(key.WithKeys("alt+left", "alt+b"), key.WithHelp("alt+left", "word backward")),
+	LineNext:                key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("down", "next line")),
+	LinePrevious:            key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("up", "previous line")),
+	DeleteWordBackward:      key.NewBinding(key.WithKeys("alt+backspace", "ctrl+w"), key.WithHelp("ctrl+w", "delete word backward")),
+	DeleteWordForward:       key.NewBinding(key.WithKeys("alt+delete", "alt+d"), key.WithHelp("alt+d", "delete word forward")),
+	DeleteAfterCursor:       key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "delete after cursor")),
+	DeleteBeforeCursor:      key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "delete before cursor")),
+	InsertNewline:           key.NewBinding(key.WithKeys("enter", "ctrl+m"), key.WithHelp("enter", "insert newline")),
+	DeleteCharacterBackward: key.NewBinding(key.WithKeys("backspace", "ctrl+h"), key.WithHelp("backspace", "delete character backward")),
+	DeleteCharacterForward:  key.NewBinding(key.WithKeys("delete", "ctrl+d"), key.WithHelp("delete", "delete character forward")),
+	LineStart:               key.NewBinding(key.WithKeys("home", "ctrl+a"), key.WithHelp("home", "line start")),
+	LineEnd:                 key.NewBinding(key.WithKeys("end", "ctrl+e"), key.WithHelp("end", "line end")),
+	Paste:                   key.NewBinding(key.WithKeys("ctrl+v"), key.WithHelp("ctrl+v", "paste")),
+	InputBegin:              key.NewBinding(key.WithKeys("alt+<", "ctrl+home"), key.WithHelp("ctrl+home", "input begin")),
+	InputEnd:                key.NewBinding(key.WithKeys("alt+>", "ctrl+end"), key.WithHelp("ctrl+end", "input end")),
+}
+
+// ShortHelp returns the bindings for getting around the textarea.
+func (keyMap KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keyMap.LineNext, keyMap.LinePrevious, keyMap.WordForward, keyMap.WordBackward}
+}
+
+// FullHelp returns all the bindings, grouped into movement and editing.
+func (keyMap KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			keyMap.CharacterForward,
+			keyMap.CharacterBackward,
+			keyMap.WordForward,
+			keyMap.WordBackward,
+			keyMap.LineNext,
+			keyMap.LinePrevious,
+			keyMap.LineStart,
+			keyMap.LineEnd,
+			keyMap.InputBegin,
+			keyMap.InputEnd,
+		},
+		{
+			keyMap.InsertNewline,
+			keyMap.DeleteCharacterBackward,
+			keyMap.DeleteCharacterForward,
+			keyMap.DeleteWordBackward,
+			keyMap.DeleteWordForward,
+			keyMap.DeleteBeforeCursor,
+			keyMap.DeleteAfterCursor,
+			keyMap.Paste,
+		},
+	}
+}
+
+// GetBindingsByAction returns the bindings keyed by the action names used in key
+// map config files.
+func (keyMap *KeyMap) GetBindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"character_backward":        &keyMap.CharacterBackward,
+		"character_forward":         &keyMap.CharacterForward,
+		"delete_after_cursor":       &keyMap.DeleteAfterCursor,
+		"delete_before_cursor":      &keyMap.DeleteBeforeCursor,
+		"delete_character_backward": &keyMap.DeleteCharacterBackward,
+		"delete_character_forward":  &keyMap.DeleteCharacterForward,
+		"delete_word_backward":      &keyMap.DeleteWordBackward,
+		"delete_word_forward":       &keyMap.DeleteWordForward,
+		"insert_newline":            &keyMap.InsertNewline,
+		"line_end":                  &keyMap.LineEnd,
+		"line_next":                 &keyMap.LineNext,
+		"line_previous":             &keyMap.LinePrevious,
+		"line_start":                &keyMap.LineStart,
+		"paste":                     &keyMap.Paste,
+		"word_backward":             &keyMap.WordBackward,
+		"word_forward":              &keyMap.WordForward,
+		"input_begin":               &keyMap.InputBegin,
+		"input_end":                 &keyMap.InputEnd,
+	}
+}
+
+func init() {
+	bubble_bath.RegisterKeyMapType(KeyMapType, &DefaultKeyMap)
 }
@@ -1,6 +1,11 @@
 package textarea
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
 
 // Style that will be applied to the text area.
 //
@@ -21,28 +26,59 @@ type Style struct {
 }
 
 // DefaultStyles returns the default styles for focused and blurred states for
-// the textarea.
-func DefaultStyles() (Style, Style) {
+// the textarea, with their colors taken from the theme.
+func DefaultStyles(theme bubble_bath.Theme) (Style, Style) {
 	focused := Style{
 		Base:             lipgloss.NewStyle(),
-		CursorLine:       lipgloss.NewStyle().Background(lipgloss.AdaptiveColor{Light: "255", Dark: "0"}),
-		CursorLineNumber: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "240"}),
-		EndOfBuffer:      lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "254", Dark: "0"}),
-		LineNumber:       lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "249", Dark: "7"}),
-		Placeholder:      lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
-		Prompt:           lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
+		CursorLine:       lipgloss.NewStyle().Background(theme.Subtle),
+		CursorLineNumber: lipgloss.NewStyle().Foreground(theme.Foreground),
+		EndOfBuffer:      lipgloss.NewStyle().Foreground(theme.Subtle),
+		LineNumber:       lipgloss.NewStyle().Foreground(theme.Muted),
+		Placeholder:      lipgloss.NewStyle().Foreground(theme.Muted),
+		Prompt:           lipgloss.NewStyle().Foreground(theme.Muted),
 		Text:             lipgloss.NewStyle(),
 	}
 	blurred := Style{
 		Base:             lipgloss.NewStyle(),
-		CursorLine:       lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "7"}),
-		CursorLineNumber: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "249", Dark: "7"}),
-		EndOfBuffer:      lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "254", Dark: "0"}),
-		LineNumber:       lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "249", Dark: "7"}),
-		Placeholder:      lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
-		Prompt:           lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
-		Text:             lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "7"}),
+		CursorLine:       lipgloss.NewStyle().Foreground(theme.Muted),
+		CursorLineNumber: lipgloss.NewStyle().Foreground(theme.Muted),
+		EndOfBuffer:      lipgloss.NewStyle().Foreground(theme.Subtle),
+		LineNumber:       lipgloss.NewStyle().Foreground(theme.Muted),
+		Placeholder:      lipgloss.NewStyle().Foreground(theme.Muted),
+		Prompt:           lipgloss.NewStyle().Foreground(theme.Muted),
+		Text:             lipgloss.NewStyle().Foreground(theme.Muted),
 	}
 
 	return focused, blurred
 }
+
+// defaultCursorStyle returns the default style of the cursor, with its color
+// taken from the theme.
+func defaultCursorStyle(theme bubble_bath.Theme) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Accent)
+}
+
+// rethemed returns the style switched over from the old theme's defaults to
+// the new theme's. Any part of the style that was customized away from the old
+// theme's default is kept as-is.
+func (s Style) rethemed(oldDefault Style, newDefault Style) Style {
+	return Style{
+		Base:             rethemed(s.Base, oldDefault.Base, newDefault.Base),
+		CursorLine:       rethemed(s.CursorLine, oldDefault.CursorLine, newDefault.CursorLine),
+		CursorLineNumber: rethemed(s.CursorLineNumber, oldDefault.CursorLineNumber, newDefault.CursorLineNumber),
+		EndOfBuffer:      rethemed(s.EndOfBuffer, oldDefault.EndOfBuffer, newDefault.EndOfBuffer),
+		LineNumber:       rethemed(s.LineNumber, oldDefault.LineNumber, newDefault.LineNumber),
+		Placeholder:      rethemed(s.Placeholder, oldDefault.Placeholder, newDefault.Placeholder),
+		Prompt:           rethemed(s.Prompt, oldDefault.Prompt, newDefault.Prompt),
+		Text:             rethemed(s.Text, oldDefault.Text, newDefault.Text),
+	}
+}
+
+// rethemed returns the new default if the style is still the old default, or
+// the (customized) style otherwise.
+func rethemed(style lipgloss.Style, oldDefault lipgloss.Style, newDefault lipgloss.Style) lipgloss.Style {
+	if reflect.DeepEqual(style, oldDefault) {
+		return newDefault
+	}
+	return style
+}
@@ -0,0 +1,31 @@
+package textarea
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+func TestSetThemeKeepsCustomizedStyles(t *testing.T) {
+	textArea := New()
+	textArea.SetTheme(bubble_bath.DarkTheme)
+
+	customText := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	focused, blurred := textArea.GetStyles()
+	focused.Text = customText
+	textArea.SetStyles(focused, blurred)
+
+	textArea.SetTheme(bubble_bath.LightTheme)
+
+	actualFocused, actualBlurred := textArea.GetStyles()
+	expectedFocused, expectedBlurred := DefaultStyles(bubble_bath.LightTheme)
+	expectedFocused.Text = customText
+	if !reflect.DeepEqual(actualFocused, expectedFocused) {
+		t.Errorf("Expected the focused style to keep its custom text style & take the rest from the new theme, but got %+v", actualFocused)
+	}
+	if !reflect.DeepEqual(actualBlurred, expectedBlurred) {
+		t.Errorf("Expected the blurred style to be the new theme's default, but got %+v", actualBlurred)
+	}
+}
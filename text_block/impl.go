@@ -1,9 +1,17 @@
 package text_block
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// StyleType is the name that style sheet type selectors use to match text blocks
+const StyleType = "text_block"
 
 type Option func(*implementation)
 
+// WithStyle sets an inline style for the text, which is applied inside whatever style the style sheet gives the block
+// (the way an HTML element's inline style beats its CSS)
 func WithStyle(style lipgloss.Style) Option {
 	return func(impl *implementation) {
 		impl.style = style
@@ -11,6 +19,8 @@ func WithStyle(style lipgloss.Style) Option {
 }
 
 type implementation struct {
+	bubble_bath.StyleNode
+
 	style lipgloss.Style
 
 	contents string
@@ -23,10 +33,11 @@ type implementation struct {
 
 func New(contents string, options ...Option) Component {
 	result := &implementation{
-		style:    lipgloss.Style{},
-		contents: contents,
-		width:    0,
-		height:   0,
+		StyleNode: bubble_bath.StyleNode{},
+		style:     lipgloss.Style{},
+		contents:  contents,
+		width:     0,
+		height:    0,
 	}
 	for _, opt := range options {
 		opt(result)
@@ -40,10 +51,7 @@ func (item *implementation) GetContents() string {
 
 func (item *implementation) View() string {
 	// TODO add the nice '...' for when the item is cut off
-	return item.style.
-		MaxWidth(item.width).
-		MaxHeight(item.height).
-		Render(item.contents)
+	return item.GetStyle().RenderWithin(item.style.Render(item.contents), item.width, item.height)
 }
 
 func (item *implementation) Resize(width int, height int) {
@@ -58,3 +66,26 @@ func (item *implementation) GetWidth() int {
 func (item *implementation) GetHeight() int {
 	return item.height
 }
+
+func (item *implementation) GetMinimumIntrinsicWidth() int {
+	// Text blocks truncate rather than wrap, so they can be squeezed all the way down
+	return 0
+}
+
+func (item *implementation) GetMaximumIntrinsicWidth() int {
+	return lipgloss.Width(item.style.Render(item.contents)) + item.GetStyle().GetHorizontalFrameSize()
+}
+
+func (item *implementation) GetHeightGivenWidth(width int) int {
+	// No wrapping is done, so the width doesn't affect the height
+	return lipgloss.Height(item.style.Render(item.contents)) + item.GetStyle().GetVerticalFrameSize()
+}
+
+func (item *implementation) GetStyleType() string {
+	return StyleType
+}
+
+func (item *implementation) HasStyleState(state bubble_bath.StyleState) bool {
+	// Text blocks are static, so they're never in any state
+	return false
+}
@@ -5,7 +5,8 @@ import (
 )
 
 type Component interface {
-	bubble_bath.Component
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.StylableComponent
 
 	// GetContents gets the raw contents of the text block, without truncation
 	GetContents() string
@@ -1,12 +1,21 @@
 package text_input
 
 import (
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/mieubrisse/bubble-bath"
 )
 
 type Component interface {
 	bubble_bath.InteractiveComponent
+	bubble_bath.IntrinsicallySizedComponent
+	bubble_bath.StylableComponent
+	bubble_bath.ThemedComponent
+	bubble_bath.KeyBindingProvider
+	bubble_bath.KeyRemappableComponent
 
 	GetValue() string
 	SetValue(value string)
+
+	GetKeyMap() textinput.KeyMap
+	SetKeyMap(keyMap textinput.KeyMap)
 }
@@ -0,0 +1,95 @@
+package text_input
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	bubble_bath "github.com/mieubrisse/bubble-bath"
+)
+
+// KeyMapType is the name that key map config files use for the input's bindings
+const KeyMapType = "text_input"
+
+// DefaultKeyMap is the bubbles text input's default bindings, with help text added
+var DefaultKeyMap = textinput.KeyMap{
+	CharacterForward:        key.NewBinding(key.WithKeys("right", "ctrl+f"), key.WithHelp("right", "character forward")),
+	CharacterBackward:       key.NewBinding(key.WithKeys("left", "ctrl+b"), key.WithHelp("left", "character backward")),
+	WordForward:             key.NewBinding(key.WithKeys("alt+right", "alt+f"), key.WithHelp("alt+right", "word forward")),
+	WordBackward:            key.NewBinding(key.WithKeys("alt+left", "alt+b"), key.WithHelp("alt+left", "word backward")),
+	DeleteWordBackward:      key.NewBinding(key.WithKeys("alt+backspace", "ctrl+w"), key.WithHelp("ctrl+w", "delete word backward")),
+	DeleteWordForward:       key.NewBinding(key.WithKeys("alt+delete", "alt+d"), key.WithHelp("alt+d", "delete word forward")),
+	DeleteAfterCursor:       key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "delete after cursor")),
+	DeleteBeforeCursor:      key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "delete before cursor")),
+	DeleteCharacterBackward: key.NewBinding(key.WithKeys("backspace", "ctrl+h"), key.WithHelp("backspace", "delete character backward")),
+	DeleteCharacterForward:  key.NewBinding(key.WithKeys("delete", "ctrl+d"), key.WithHelp("delete", "delete character forward")),
+	LineStart:               key.NewBinding(key.WithKeys("home", "ctrl+a"), key.WithHelp("home", "start")),
+	LineEnd:                 key.NewBinding(key.WithKeys("end", "ctrl+e"), key.WithHelp("end", "end")),
+	Paste:                   key.NewBinding(key.WithKeys("ctrl+v"), key.WithHelp("ctrl+v", "paste")),
+}
+
+// remappableKeyMap lets a KeyMapConfig remap the bubbles text input's bindings, since we can't add methods to its type
+type remappableKeyMap struct {
+	keyMap *textinput.KeyMap
+}
+
+func (remappable remappableKeyMap) GetBindingsByAction() map[string]*key.Binding {
+	keyMap := remappable.keyMap
+	return map[string]*key.Binding{
+		"character_forward":         &keyMap.CharacterForward,
+		"character_backward":        &keyMap.CharacterBackward,
+		"word_forward":              &keyMap.WordForward,
+		"word_backward":             &keyMap.WordBackward,
+		"delete_word_backward":      &keyMap.DeleteWordBackward,
+		"delete_word_forward":       &keyMap.DeleteWordForward,
+		"delete_after_cursor":       &keyMap.DeleteAfterCursor,
+		"delete_before_cursor":      &keyMap.DeleteBeforeCursor,
+		"delete_character_backward": &keyMap.DeleteCharacterBackward,
+		"delete_character_forward":  &keyMap.DeleteCharacterForward,
+		"line_start":                &keyMap.LineStart,
+		"line_end":                  &keyMap.LineEnd,
+		"paste":                     &keyMap.Paste,
+	}
+}
+
+func init() {
+	bubble_bath.RegisterKeyMapType(KeyMapType, remappableKeyMap{keyMap: &DefaultKeyMap})
+}
+
+func (model *Model) ApplyKeyMapConfig(config *bubble_bath.KeyMapConfig) {
+	config.Apply(KeyMapType, remappableKeyMap{keyMap: &model.input.KeyMap})
+}
+
+func (model Model) GetKeyMap() textinput.KeyMap {
+	return model.input.KeyMap
+}
+
+func (model *Model) SetKeyMap(keyMap textinput.KeyMap) {
+	model.input.KeyMap = keyMap
+}
+
+// ShortHelp gets nothing, since typing is self-explanatory
+func (model Model) ShortHelp() []key.Binding {
+	return []key.Binding{}
+}
+
+func (model Model) FullHelp() [][]key.Binding {
+	keyMap := model.input.KeyMap
+	return [][]key.Binding{
+		{
+			keyMap.CharacterForward,
+			keyMap.CharacterBackward,
+			keyMap.WordForward,
+			keyMap.WordBackward,
+			keyMap.LineStart,
+			keyMap.LineEnd,
+		},
+		{
+			keyMap.DeleteCharacterBackward,
+			keyMap.DeleteCharacterForward,
+			keyMap.DeleteWordBackward,
+			keyMap.DeleteWordForward,
+			keyMap.DeleteBeforeCursor,
+			keyMap.DeleteAfterCursor,
+			keyMap.Paste,
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package text_input
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -8,13 +9,19 @@ import (
 	"github.com/muesli/ansi"
 )
 
+// StyleType is the name that style sheet type selectors use to match text inputs
+// Focused inputs are in the bubble_bath.StyleStateFocused state
+const StyleType = "text_input"
+
 type Model struct {
-	UnfocusedStyle lipgloss.Style
-	FocusedStyle   lipgloss.Style
+	bubble_bath.StyleNode
 
 	input           textinput.Model
 	foregroundColor lipgloss.Color
 
+	// The theme that the input's placeholder & cursor styles were derived from
+	theme bubble_bath.Theme
+
 	isFocused bool
 	width     int
 	height    int
@@ -24,13 +31,20 @@ func New(promptText string) Model {
 	input := textinput.New()
 
 	input.Prompt = promptText
-	return Model{
+	input.KeyMap = DefaultKeyMap
+	result := Model{
+		StyleNode:       bubble_bath.StyleNode{},
 		input:           input,
 		foregroundColor: "",
+		theme:           bubble_bath.Theme{},
 		isFocused:       false,
 		width:           0,
 		height:          0,
 	}
+
+	// The program applies its theme before the first render, so there's no need to query the terminal here
+	result.SetTheme(bubble_bath.DarkTheme)
+	return result
 }
 
 func (model Model) Init() tea.Cmd {
@@ -38,6 +52,11 @@ func (model Model) Init() tea.Cmd {
 }
 
 func (model *Model) Update(msg tea.Msg) tea.Cmd {
+	if themeChangedMsg, ok := msg.(bubble_bath.ThemeChangedMsg); ok {
+		model.SetTheme(themeChangedMsg.Current)
+		return nil
+	}
+
 	if !model.isFocused {
 		return nil
 	}
@@ -47,13 +66,21 @@ func (model *Model) Update(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
-func (model Model) View() string {
-	// It'd be really nice if textinput.Model allowed us to control the style based on focus, but alas it does not
-	style := model.UnfocusedStyle
-	if model.input.Focused() {
-		style = model.FocusedStyle
+// HandleEvent edits the input for typed text and the input's editing keys, leaving every other key (e.g. enter or esc)
+// to bubble up to whatever the input is part of
+func (model *Model) HandleEvent(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !model.isFocused || !model.isEditingKey(keyMsg) {
+		return nil, false
 	}
-	return style.Render(model.input.View())
+
+	var cmd tea.Cmd
+	model.input, cmd = model.input.Update(msg)
+	return cmd, true
+}
+
+func (model Model) View() string {
+	return model.GetStyle().RenderWithin(model.input.View(), model.width, model.height)
 }
 
 func (model *Model) SetValue(newValue string) {
@@ -75,6 +102,13 @@ func (model *Model) Blur() tea.Cmd {
 	return nil
 }
 
+func (model *Model) SetFocus(isFocused bool) tea.Cmd {
+	if isFocused {
+		return model.Focus()
+	}
+	return model.Blur()
+}
+
 func (model Model) IsFocused() bool {
 	return model.isFocused
 }
@@ -83,6 +117,16 @@ func (model *Model) SetForegroundColor(color lipgloss.Color) {
 	model.foregroundColor = color
 }
 
+// SetTheme re-derives the placeholder & cursor styles from the theme
+func (model *Model) SetTheme(theme bubble_bath.Theme) {
+	if theme == model.theme {
+		return
+	}
+	model.theme = theme
+	model.input.PlaceholderStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	model.input.Cursor.Style = lipgloss.NewStyle().Foreground(theme.Accent)
+}
+
 func (model *Model) Resize(width int, height int) {
 	model.width = width
 	model.height = height
@@ -91,7 +135,7 @@ func (model *Model) Resize(width int, height int) {
 
 	// I'm not actually sure why we need the extra - 1 here (something to do with how Charm renders the max width); if we
 	// don't have it though, things get weird
-	maxNumDesiredDisplayedChars := width - promptPrintableLength - 1
+	maxNumDesiredDisplayedChars := width - model.GetStyle().GetHorizontalFrameSize() - promptPrintableLength - 1
 	maxNumActualDisplayedChars := bubble_bath.GetMaxInt(0, maxNumDesiredDisplayedChars)
 
 	// The width on the Charm input is actually the max number of characters displayed at once NOT including the prompt!
@@ -99,6 +143,39 @@ func (model *Model) Resize(width int, height int) {
 	model.input.Width = maxNumActualDisplayedChars
 }
 
+func (model Model) GetMinimumIntrinsicWidth() int {
+	// Enough for the prompt plus a single displayed character (and the mysterious extra 1 from Resize)
+	return ansi.PrintableRuneWidth(model.input.Prompt) + 2 + model.GetStyle().GetHorizontalFrameSize()
+}
+
+func (model Model) GetMaximumIntrinsicWidth() int {
+	// Enough for the prompt plus the entire value (and the mysterious extra 1 from Resize)
+	frameSize := model.GetStyle().GetHorizontalFrameSize()
+	return ansi.PrintableRuneWidth(model.input.Prompt) + ansi.PrintableRuneWidth(model.input.Value()) + 2 + frameSize
+}
+
+func (model Model) GetHeightGivenWidth(width int) int {
+	// Text inputs scroll horizontally rather than wrapping
+	return 1 + model.GetStyle().GetVerticalFrameSize()
+}
+
+func (model Model) GetStyleType() string {
+	return StyleType
+}
+
+func (model Model) HasStyleState(state bubble_bath.StyleState) bool {
+	return state == bubble_bath.StyleStateFocused && model.isFocused
+}
+
+// SetStyle sets the style, resizing the input if the style's margins, borders, or padding changed its space
+func (model *Model) SetStyle(style bubble_bath.Style) {
+	oldStyle := model.GetStyle()
+	model.StyleNode.SetStyle(style)
+	if style.GetHorizontalFrameSize() != oldStyle.GetHorizontalFrameSize() {
+		model.Resize(model.width, model.height)
+	}
+}
+
 func (model Model) GetHeight() int {
 	return model.height
 }
@@ -106,3 +183,20 @@ func (model Model) GetHeight() int {
 func (model Model) GetWidth() int {
 	return model.width
 }
+
+// ====================================================================================================
+//                                   Private Helper Functions
+// ====================================================================================================
+
+// isEditingKey indicates whether the key types text or matches one of the input's bindings
+func (model Model) isEditingKey(msg tea.KeyMsg) bool {
+	if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+		return true
+	}
+	for _, binding := range (remappableKeyMap{keyMap: &model.input.KeyMap}).GetBindingsByAction() {
+		if key.Matches(msg, *binding) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package bubble_bath
+
+import (
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a palette of semantic colors that the built-in components (and the default style sheet) derive their
+// styles from, so that an app's look can be changed wholesale without touching component code
+type Theme struct {
+	Name string
+
+	// Regular text & the app's background
+	Foreground lipgloss.TerminalColor
+	Background lipgloss.TerminalColor
+
+	// Less-important text (e.g. placeholders, line numbers)
+	Muted lipgloss.TerminalColor
+
+	// Barely-visible fills (e.g. the cursor line of a text area)
+	Subtle lipgloss.TerminalColor
+
+	// Draws the eye (e.g. the cursor)
+	Accent lipgloss.TerminalColor
+
+	// The background of highlighted items
+	Selection lipgloss.TerminalColor
+
+	Error   lipgloss.TerminalColor
+	Warning lipgloss.TerminalColor
+	Success lipgloss.TerminalColor
+
+	Border        lipgloss.TerminalColor
+	BorderFocused lipgloss.TerminalColor
+}
+
+// DarkTheme is for terminals with dark backgrounds
+var DarkTheme = Theme{
+	Name:          "dark",
+	Foreground:    lipgloss.Color("#d0d0d0"),
+	Background:    lipgloss.NoColor{},
+	Muted:         lipgloss.Color("#808080"),
+	Subtle:        lipgloss.Color("#1c1c1c"),
+	Accent:        lipgloss.Color("#5fafff"),
+	Selection:     lipgloss.Color("#282828"),
+	Error:         lipgloss.Color("#ff5f5f"),
+	Warning:       lipgloss.Color("#ffaf5f"),
+	Success:       lipgloss.Color("#87d787"),
+	Border:        lipgloss.Color("#585858"),
+	BorderFocused: lipgloss.Color("#5fafff"),
+}
+
+// LightTheme is for terminals with light backgrounds
+var LightTheme = Theme{
+	Name:          "light",
+	Foreground:    lipgloss.Color("#303030"),
+	Background:    lipgloss.NoColor{},
+	Muted:         lipgloss.Color("#8a8a8a"),
+	Subtle:        lipgloss.Color("#eeeeee"),
+	Accent:        lipgloss.Color("#005fd7"),
+	Selection:     lipgloss.Color("#dadada"),
+	Error:         lipgloss.Color("#d70000"),
+	Warning:       lipgloss.Color("#d75f00"),
+	Success:       lipgloss.Color("#008700"),
+	Border:        lipgloss.Color("#bcbcbc"),
+	BorderFocused: lipgloss.Color("#005fd7"),
+}
+
+var detectedTheme Theme
+var detectThemeOnce sync.Once
+
+// DetectTheme picks DarkTheme or LightTheme based on the terminal's background color (assuming dark if the terminal
+// can't be queried)
+// Querying the terminal is slow so it's only done once, and later calls get the same answer
+func DetectTheme() Theme {
+	detectThemeOnce.Do(func() {
+		detectedTheme = LightTheme
+		if lipgloss.HasDarkBackground() {
+			detectedTheme = DarkTheme
+		}
+	})
+	return detectedTheme
+}
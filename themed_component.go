@@ -0,0 +1,60 @@
+package bubble_bath
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ThemedComponent is a component whose styles are derived from a Theme
+// The program sets the theme of every ThemedComponent in the tree before each render (the way it applies the style
+// sheet), so SetTheme should be cheap when the theme hasn't changed
+// Interactive ThemedComponents should also re-derive their styles when they get a ThemeChangedMsg
+type ThemedComponent interface {
+	Component
+
+	SetTheme(theme Theme)
+}
+
+// ApplyTheme sets the theme of every ThemedComponent in the tree rooted at the given component, hidden children included
+// (see HiddenChildrenContainerComponent)
+func ApplyTheme(root Component, theme Theme) {
+	if themedComponent, ok := root.(ThemedComponent); ok {
+		themedComponent.SetTheme(theme)
+	}
+	for _, child := range getAllChildren(root) {
+		ApplyTheme(child, theme)
+	}
+}
+
+// ChangeThemeMsg asks the program to switch the app's theme
+type ChangeThemeMsg struct {
+	Theme Theme
+}
+
+// ChangeTheme is a tea.Cmd factory that components can use to switch the app's theme live (e.g. a key binding that
+// toggles between DarkTheme and LightTheme)
+func ChangeTheme(theme Theme) tea.Cmd {
+	return func() tea.Msg {
+		return ChangeThemeMsg{Theme: theme}
+	}
+}
+
+// ThemeChangedMsg is broadcast through the whole component tree when the program switches the app's theme, so that
+// components can re-derive their styles from it
+// Unlike most messages, containers pass it to all their children rather than only the focused ones (see
+// BroadcastThemeChange)
+type ThemeChangedMsg struct {
+	Previous Theme
+	Current  Theme
+}
+
+// BroadcastThemeChange passes the message on to each of a container's children: InteractiveComponents get it through
+// Update, and the themes of everything else (and its descendants) get set directly since it can't receive messages
+func BroadcastThemeChange(children []Component, msg ThemeChangedMsg) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(children))
+	for _, child := range children {
+		if interactiveChild, ok := child.(InteractiveComponent); ok {
+			cmds = append(cmds, interactiveChild.Update(msg))
+			continue
+		}
+		ApplyTheme(child, msg.Current)
+	}
+	return tea.Batch(cmds...)
+}
@@ -1,5 +1,7 @@
 package bubble_bath
 
+import "math"
+
 func GetMaxInt(a, b int) int {
 	if a > b {
 		return a
@@ -20,3 +22,18 @@ func Clamp(value, low, high int) int {
 	}
 	return GetMinInt(high, GetMaxInt(low, value))
 }
+
+// RoundPreservingSum rounds the sizes to integers such that the rounded sizes add up to the rounded total, so that
+// rounding errors don't accumulate into gaps or overflows (e.g. when splitting space between flexible items)
+func RoundPreservingSum(sizes []float64) []int {
+	results := make([]int, len(sizes))
+	cumulativeSize := 0.0
+	previousRoundedCumulativeSize := 0
+	for idx, size := range sizes {
+		cumulativeSize += size
+		roundedCumulativeSize := int(math.Round(cumulativeSize))
+		results[idx] = roundedCumulativeSize - previousRoundedCumulativeSize
+		previousRoundedCumulativeSize = roundedCumulativeSize
+	}
+	return results
+}
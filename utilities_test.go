@@ -0,0 +1,67 @@
+package bubble_bath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundPreservingSum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sizes    []float64
+		expected []int
+	}{
+		{
+			name:     "empty",
+			sizes:    []float64{},
+			expected: []int{},
+		},
+		{
+			name:     "whole sizes are unchanged",
+			sizes:    []float64{3, 4, 5},
+			expected: []int{3, 4, 5},
+		},
+		{
+			name:     "thirds still add up to the total",
+			sizes:    []float64{10.0 / 3, 10.0 / 3, 10.0 / 3},
+			expected: []int{3, 4, 3},
+		},
+		{
+			name:     "halves alternate rather than all rounding up",
+			sizes:    []float64{1.5, 1.5, 1.5, 1.5},
+			expected: []int{2, 1, 2, 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := RoundPreservingSum(testCase.sizes)
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int
+		low      int
+		high     int
+		expected int
+	}{
+		{name: "within bounds", value: 5, low: 0, high: 10, expected: 5},
+		{name: "below low", value: -3, low: 0, high: 10, expected: 0},
+		{name: "above high", value: 13, low: 0, high: 10, expected: 10},
+		{name: "swapped bounds", value: 13, low: 10, high: 0, expected: 10},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := Clamp(testCase.value, testCase.low, testCase.high); actual != testCase.expected {
+				t.Errorf("Expected %v but got %v", testCase.expected, actual)
+			}
+		})
+	}
+}